@@ -0,0 +1,44 @@
+package arc
+
+import "time"
+
+/*
+Example Callback (single):
+
+{
+  "timestamp": "2023-08-10T13:49:07.308687569Z",
+  "txid": "6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0",
+  "txStatus": "MINED",
+  "extraInfo": "",
+  "competingTxs": null,
+  "blockHash": "0000000000000000064cbaac5cedf71a5447771573ba585501952c023873817b",
+  "blockHeight": 807650,
+  "merklePath": "fe636d0c0007021400fe507c0c7aa754cef1f..."
+}
+
+Example BatchCallback (X-CallbackBatch):
+
+{
+  "count": 2,
+  "callbacks": [{...}, {...}]
+}
+*/
+
+// Callback is the body contents posted to the provided callback url from Arc
+type Callback struct {
+	BlockHash    string    `json:"blockHash,omitempty"`
+	BlockHeight  int64     `json:"blockHeight,omitempty"`
+	CompetingTxs []string  `json:"competingTxs,omitempty"`
+	ExtraInfo    string    `json:"extraInfo,omitempty"`
+	MerklePath   string    `json:"merklePath,omitempty"`
+	Timestamp    time.Time `json:"timestamp,omitempty"`
+	TxStatus     TxStatus  `json:"txStatus,omitempty"`
+	TxID         string    `json:"txid,omitempty"`
+}
+
+// BatchCallback is the body contents posted to the provided callback url from Arc
+// when the callbacks are batched (X-CallbackBatch)
+type BatchCallback struct {
+	Count     int         `json:"count"`
+	Callbacks []*Callback `json:"callbacks"`
+}
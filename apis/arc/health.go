@@ -0,0 +1,18 @@
+package arc
+
+/*
+Example Health response:
+
+{
+  "healthy": true,
+  "reason": "no error",
+  "version": "v1.1.53"
+}
+*/
+
+// HealthModel is the unmarshalled version of the health response
+type HealthModel struct {
+	Healthy bool   `json:"healthy"`
+	Reason  string `json:"reason,omitempty"`
+	Version string `json:"version,omitempty"`
+}
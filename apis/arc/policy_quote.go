@@ -1,6 +1,27 @@
 package arc
 
-import "github.com/libsv/go-bt/v2"
+import (
+	"encoding/json"
+
+	"github.com/libsv/go-bt/v2"
+)
+
+/*
+Example policy response from Arc:
+
+{
+  "policy": {
+    "maxscriptsizepolicy": 100000000,
+    "maxtxsigopscountspolicy": 4294967295,
+    "maxtxsizepolicy": 100000000,
+    "miningFee": {
+      "bytes": 1000,
+      "satoshis": 1
+    }
+  },
+  "timestamp": "2023-08-10T13:49:07.308687569Z"
+}
+*/
 
 // Policy is the unmarshalled version of the payload envelope
 type Policy struct {
@@ -15,3 +36,29 @@ type PolicyQuoteModel struct {
 	Policy    Policy `json:"policy"`
 	Timestamp string `json:"timestamp"`
 }
+
+// UnmarshalJSON will unmarshal the Arc policy
+//
+// Arc returns the mining fee as a single fee unit (satoshis per bytes), so it is
+// converted into a standard bt.Fee. Arc has no separate relay fee, so the mining
+// fee unit is used for both.
+func (p *Policy) UnmarshalJSON(data []byte) error {
+	type policyAlias Policy
+	raw := struct {
+		*policyAlias
+		MiningFee *bt.FeeUnit `json:"miningFee"`
+	}{policyAlias: (*policyAlias)(p)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.MiningFee != nil {
+		p.MiningFee = &bt.Fee{
+			FeeType:   bt.FeeTypeStandard,
+			MiningFee: *raw.MiningFee,
+			RelayFee:  *raw.MiningFee,
+		}
+	}
+	return nil
+}
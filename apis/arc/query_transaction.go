@@ -4,8 +4,9 @@ import "time"
 
 // QueryTxModel is the unmarshalled version of the payload envelope
 type QueryTxModel struct {
-	BlockHash   string `json:"blockHash,omitempty"`
-	BlockHeight int64  `json:"blockHeight,omitempty"`
+	BlockHash    string   `json:"blockHash,omitempty"`
+	BlockHeight  int64    `json:"blockHeight,omitempty"`
+	CompetingTxs []string `json:"competingTxs,omitempty"`
 	// TODO: Specify the type - currently no information on this in the docs
 	ExtraInfo struct{}  `json:"extraInfo,omitempty"`
 	Timestamp time.Time `json:"timestamp,omitempty"`
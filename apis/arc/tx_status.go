@@ -1,6 +1,13 @@
 package arc
 
+import (
+	"encoding/json"
+	"strconv"
+)
+
 // TxStatus is the status of the transaction
+//
+// Unknown statuses (returned by newer Arc servers) are kept as-is.
 type TxStatus string
 
 // List of statuses available here: https://github.com/bitcoin-sv/arc
@@ -21,41 +28,119 @@ const (
 	SentToNetwork TxStatus = "SENT_TO_NETWORK" // 6
 	// AcceptedByNetwork contains value for accepted by network status
 	AcceptedByNetwork TxStatus = "ACCEPTED_BY_NETWORK" // 7
+	// SeenInOrphanMempool contains value for seen in orphan mempool status (missing parents)
+	SeenInOrphanMempool TxStatus = "SEEN_IN_ORPHAN_MEMPOOL"
 	// SeenOnNetwork contains value for seen on network status
 	SeenOnNetwork TxStatus = "SEEN_ON_NETWORK" // 8
+	// DoubleSpendAttempted contains value for double spend attempted status (see competingTxs)
+	DoubleSpendAttempted TxStatus = "DOUBLE_SPEND_ATTEMPTED"
+	// Rejected contains value for rejected status
+	Rejected TxStatus = "REJECTED" // 109
+	// MinedInStaleBlock contains value for mined in stale block status (the block was orphaned)
+	MinedInStaleBlock TxStatus = "MINED_IN_STALE_BLOCK"
 	// Mined contains value for mined status
 	Mined TxStatus = "MINED" // 9
 	// Confirmed contains value for confirmed status
 	Confirmed TxStatus = "CONFIRMED" // 108
-	// Rejected contains value for rejected status
-	Rejected TxStatus = "REJECTED" // 109
 )
 
+// txStatusOrder is the position of the statuses in the transaction lifecycle
+//
+// The failure and stale statuses are not part of the lifecycle (see AtLeast).
+var txStatusOrder = map[TxStatus]int{
+	Unknown:             0,
+	Queued:              1,
+	Received:            2,
+	Stored:              3,
+	AnnouncedToNetwork:  4,
+	RequestedByNetwork:  5,
+	SentToNetwork:       6,
+	AcceptedByNetwork:   7,
+	SeenInOrphanMempool: 8,
+	SeenOnNetwork:       9,
+	Mined:               10,
+	Confirmed:           11,
+}
+
+// arcStatusCodes are the numeric status codes returned by the newer Arc servers
+var arcStatusCodes = map[int]TxStatus{
+	0:   Unknown,
+	10:  Queued,
+	20:  Received,
+	30:  Stored,
+	40:  AnnouncedToNetwork,
+	50:  RequestedByNetwork,
+	60:  SentToNetwork,
+	70:  AcceptedByNetwork,
+	80:  SeenInOrphanMempool,
+	90:  SeenOnNetwork,
+	100: DoubleSpendAttempted,
+	110: Rejected,
+	115: MinedInStaleBlock,
+	120: Mined,
+}
+
 // String returns the string representation of the TxStatus
 func (s TxStatus) String() string {
-	statuses := map[TxStatus]string{
-		Unknown:            "UNKNOWN",
-		Queued:             "QUEUED",
-		Received:           "RECEIVED",
-		Stored:             "STORED",
-		AnnouncedToNetwork: "ANNOUNCED_TO_NETWORK",
-		RequestedByNetwork: "REQUESTED_BY_NETWORK",
-		SentToNetwork:      "SENT_TO_NETWORK",
-		AcceptedByNetwork:  "ACCEPTED_BY_NETWORK",
-		SeenOnNetwork:      "SEEN_ON_NETWORK",
-		Mined:              "MINED",
-		Confirmed:          "CONFIRMED",
-		Rejected:           "REJECTED",
+	return string(s)
+}
+
+// IsKnown returns true if the status is one of the known statuses
+func (s TxStatus) IsKnown() bool {
+	_, ok := txStatusOrder[s]
+	return ok || s.IsFailure() || s == MinedInStaleBlock
+}
+
+// AtLeast returns true if the status is the same or further in the lifecycle than the given status
+//
+// The failure statuses (see IsFailure) and MinedInStaleBlock are not progress, they are never
+// at least another status and no status is at least them. Unknown statuses are never at least
+// another status either.
+func (s TxStatus) AtLeast(status TxStatus) bool {
+	order, ok := txStatusOrder[s]
+	if !ok {
+		return false
 	}
+	minOrder, ok := txStatusOrder[status]
+	return ok && order >= minOrder
+}
 
-	if status, ok := statuses[s]; ok {
-		return status
+// IsTerminal returns true if the status will not change anymore (mined, confirmed or rejected)
+func (s TxStatus) IsTerminal() bool {
+	return s == Mined || s == Confirmed || s == Rejected
+}
+
+// IsFailure returns true if the transaction was rejected or a double spend was attempted
+func (s TxStatus) IsFailure() bool {
+	return s == Rejected || s == DoubleSpendAttempted
+}
+
+// IsMinedOrBetter returns true if the transaction is mined (or confirmed)
+func (s TxStatus) IsMinedOrBetter() bool {
+	return s == Mined || s == Confirmed
+}
+
+// UnmarshalJSON will unmarshal the status from a string or from an Arc status code
+func (s *TxStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*s = TxStatus(value)
+		return nil
 	}
 
-	return "Can't parse status"
+	var code int
+	if err := json.Unmarshal(data, &code); err != nil {
+		return err
+	}
+	if status, ok := arcStatusCodes[code]; ok {
+		*s = status
+		return nil
+	}
+	*s = TxStatus(strconv.Itoa(code))
+	return nil
 }
 
-// MapTxStatusToInt maps the TxStatus to an int value
+// MapTxStatusToInt maps the TxStatus to an int value (used for X-WaitForStatus)
 func MapTxStatusToInt(status TxStatus) (int, bool) {
 	waitForStatusMap := map[TxStatus]int{
 		Unknown:            0,
@@ -0,0 +1,122 @@
+package arc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTxStatus_AtLeast tests the method AtLeast()
+func TestTxStatus_AtLeast(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, SeenOnNetwork.AtLeast(SeenOnNetwork))
+	assert.True(t, Mined.AtLeast(SeenOnNetwork))
+	assert.True(t, SeenOnNetwork.AtLeast(SeenInOrphanMempool))
+	assert.True(t, Confirmed.AtLeast(Mined))
+	assert.False(t, Stored.AtLeast(SeenOnNetwork))
+	assert.False(t, TxStatus("NEW_STATUS").AtLeast(Queued))
+	assert.False(t, Mined.AtLeast("NEW_STATUS"))
+
+	// The failure and stale statuses are not progress
+	for _, status := range []TxStatus{DoubleSpendAttempted, Rejected, MinedInStaleBlock} {
+		assert.False(t, status.AtLeast(SeenOnNetwork), status)
+		assert.False(t, status.AtLeast(Queued), status)
+		assert.False(t, status.AtLeast(status), status)
+		assert.False(t, Mined.AtLeast(status), status)
+		assert.False(t, Confirmed.AtLeast(status), status)
+	}
+	assert.False(t, MinedInStaleBlock.AtLeast(Rejected))
+}
+
+// TestTxStatus_Helpers tests the lifecycle helpers
+func TestTxStatus_Helpers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status          TxStatus
+		isTerminal      bool
+		isFailure       bool
+		isMinedOrBetter bool
+	}{
+		{status: Queued},
+		{status: SeenInOrphanMempool},
+		{status: SeenOnNetwork},
+		{status: DoubleSpendAttempted, isFailure: true},
+		{status: Rejected, isTerminal: true, isFailure: true},
+		{status: MinedInStaleBlock},
+		{status: Mined, isTerminal: true, isMinedOrBetter: true},
+		{status: Confirmed, isTerminal: true, isMinedOrBetter: true},
+		{status: "NEW_STATUS"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status.String(), func(t *testing.T) {
+			assert.Equal(t, test.isTerminal, test.status.IsTerminal())
+			assert.Equal(t, test.isFailure, test.status.IsFailure())
+			assert.Equal(t, test.isMinedOrBetter, test.status.IsMinedOrBetter())
+		})
+	}
+}
+
+// TestTxStatus_String tests the method String()
+func TestTxStatus_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "SEEN_ON_NETWORK", SeenOnNetwork.String())
+	assert.Equal(t, "NEW_STATUS", TxStatus("NEW_STATUS").String())
+	assert.True(t, DoubleSpendAttempted.IsKnown())
+	assert.False(t, TxStatus("NEW_STATUS").IsKnown())
+}
+
+// TestTxStatus_UnmarshalJSON tests the method UnmarshalJSON()
+func TestTxStatus_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]TxStatus{
+		`{"txStatus": "MINED"}`:      Mined,
+		`{"txStatus": "NEW_STATUS"}`: "NEW_STATUS",
+		`{"txStatus": 90}`:           SeenOnNetwork,
+		`{"txStatus": 100}`:          DoubleSpendAttempted,
+		`{"txStatus": 120}`:          Mined,
+		`{"txStatus": 130}`:          "130",
+		`{"txStatus": 999}`:          "999",
+	}
+
+	for body, expected := range tests {
+		t.Run(body, func(t *testing.T) {
+			var model SubmitTxModel
+			require.NoError(t, json.Unmarshal([]byte(body), &model))
+			assert.Equal(t, expected, model.TxStatus)
+		})
+	}
+
+	t.Run("invalid value", func(t *testing.T) {
+		var model SubmitTxModel
+		require.Error(t, json.Unmarshal([]byte(`{"txStatus": true}`), &model))
+	})
+}
+
+// TestMapTxStatusToInt tests the method MapTxStatusToInt()
+func TestMapTxStatusToInt(t *testing.T) {
+	t.Parallel()
+
+	for status, expected := range map[TxStatus]int{
+		Queued:        1,
+		SeenOnNetwork: 8,
+		Mined:         9,
+		Confirmed:     108,
+		Rejected:      109,
+	} {
+		code, ok := MapTxStatusToInt(status)
+		assert.True(t, ok)
+		assert.Equal(t, expected, code, status)
+	}
+
+	for _, status := range []TxStatus{SeenInOrphanMempool, DoubleSpendAttempted, MinedInStaleBlock, "NEW_STATUS"} {
+		_, ok := MapTxStatusToInt(status)
+		assert.False(t, ok, status)
+	}
+}
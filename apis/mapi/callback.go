@@ -0,0 +1,26 @@
+package mapi
+
+// Callback reasons sent by mAPI
+const (
+	// CallbackReasonMerkleProof is sent when the transaction is mined (merkleProof=true)
+	CallbackReasonMerkleProof = "merkleProof"
+	// CallbackReasonDoubleSpend is sent when a double spend of the transaction is mined (dsCheck=true)
+	CallbackReasonDoubleSpend = "doubleSpend"
+	// CallbackReasonDoubleSpendAttempt is sent when a double spend attempt is detected (dsCheck=true)
+	CallbackReasonDoubleSpendAttempt = "doubleSpendAttempt"
+)
+
+/*
+Example DoubleSpendCallbackPayload:
+
+{
+  "doubleSpendTxId": "f1f8d3de162f3558b97b052064ce1d0c45805fb6a1a6e2cb2f2b3d5e2c1b0a9f",
+  "payload": "01000000014979e6d8237d7579a19aa657a568a3db46a973f737c120dffd6a8ba9432fa3f6010000006a47304402205fc740f902ccdadc2c3323f0258895f597fb75f92b13d14dd034119bee96e5f302207fd0feb68812dfa4a8e281f9af3a5b341a6fe0d14ff27648ae58c9a8aacee7d94121027ae06a5b3fe1de495fa9d4e738e48810b8b06fa6c959a5305426f78f42b48f8cffffffff018c949800000000001976a91482932cf55b847ffa52832d2bbec2838f658f226788ac00000000"
+}
+*/
+
+// DoubleSpendCallbackPayload is the callbackPayload of the doubleSpend and doubleSpendAttempt callbacks
+type DoubleSpendCallbackPayload struct {
+	DoubleSpendTxID string `json:"doubleSpendTxId"`
+	Payload         string `json:"payload"` // Raw tx hex of the double spend
+}
@@ -0,0 +1,74 @@
+package mapi
+
+import "strings"
+
+// RejectionKind is the kind of rejection of a failed transaction submission
+type RejectionKind string
+
+// Rejection kinds of a failed transaction submission
+const (
+	// RejectionAlreadyKnown is returned when the transaction is already known by the miner
+	RejectionAlreadyKnown RejectionKind = "already_known"
+	// RejectionMissingInputs is returned when the inputs are missing (unknown parent or already spent)
+	RejectionMissingInputs RejectionKind = "missing_inputs"
+	// RejectionDoubleSpend is returned when the transaction conflicts with another transaction
+	RejectionDoubleSpend RejectionKind = "double_spend"
+	// RejectionFeeTooLow is returned when the fee is too low
+	RejectionFeeTooLow RejectionKind = "fee_too_low"
+	// RejectionNonStandard is returned when the transaction is non-standard (script, dust...)
+	RejectionNonStandard RejectionKind = "non_standard"
+	// RejectionTooLarge is returned when the transaction is too large
+	RejectionTooLarge RejectionKind = "too_large"
+	// RejectionUnknown is returned when the result description is not recognized
+	RejectionUnknown RejectionKind = "unknown"
+)
+
+// rejectionPatterns are the (lower case) result description patterns per rejection kind, in order
+var rejectionPatterns = []struct {
+	kind     RejectionKind
+	patterns []string
+}{
+	{kind: RejectionAlreadyKnown, patterns: []string{
+		"txn-already-known", "already known", "txn-already-in-mempool", "already in the mempool",
+		"already in mempool", "transaction already in block chain",
+	}},
+	{kind: RejectionDoubleSpend, patterns: []string{
+		"txn-mempool-conflict", "txn-double-spend-detected", "double spend", "double-spend",
+	}},
+	{kind: RejectionMissingInputs, patterns: []string{
+		"missing inputs", "missing-inputs", "missingorspent",
+	}},
+	{kind: RejectionFeeTooLow, patterns: []string{
+		"insufficient priority", "insufficient fee", "mempool min fee not met", "min relay fee not met",
+		"fee too low", "not enough fees",
+	}},
+	{kind: RejectionNonStandard, patterns: []string{
+		"non-standard", "nonstandard", "non standard", "dust", "scriptsig-not-pushonly",
+		"scriptpubkey", "non-mandatory-script-verify-flag", "tx-size-small",
+	}},
+	{kind: RejectionTooLarge, patterns: []string{
+		"too large", "too-large", "oversize", "tx-size", // after "tx-size-small"
+	}},
+}
+
+// ClassifyRejection will map a mAPI result description (ex: "258: txn-mempool-conflict")
+// to a RejectionKind, RejectionUnknown is returned if it's not recognized
+func ClassifyRejection(resultDescription string) RejectionKind {
+	description := strings.ToLower(resultDescription)
+	for _, rejection := range rejectionPatterns {
+		for _, pattern := range rejection.patterns {
+			if strings.Contains(description, pattern) {
+				return rejection.kind
+			}
+		}
+	}
+	return RejectionUnknown
+}
+
+// RejectionKindOf will return the rejection kind of a submission result, empty if it's not a failure
+func RejectionKindOf(returnResult, resultDescription string) RejectionKind {
+	if returnResult != "failure" {
+		return ""
+	}
+	return ClassifyRejection(resultDescription)
+}
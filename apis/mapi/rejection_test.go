@@ -0,0 +1,46 @@
+package mapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClassifyRejection tests the method ClassifyRejection()
+func TestClassifyRejection(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]RejectionKind{
+		"257: txn-already-known":                          RejectionAlreadyKnown,
+		"Transaction already in the mempool":              RejectionAlreadyKnown,
+		"Missing inputs":                                  RejectionMissingInputs,
+		"16: bad-txns-inputs-missingorspent":              RejectionMissingInputs,
+		"258: txn-mempool-conflict":                       RejectionDoubleSpend,
+		"18: txn-double-spend-detected":                   RejectionDoubleSpend,
+		"66: insufficient priority":                       RejectionFeeTooLow,
+		"Not enough fees":                                 RejectionFeeTooLow,
+		"64: scriptpubkey":                                RejectionNonStandard,
+		"64: dust":                                        RejectionNonStandard,
+		"Transaction is non-standard":                     RejectionNonStandard,
+		"64: tx-size":                                     RejectionTooLarge,
+		"64: tx-size-small":                               RejectionNonStandard,
+		"Transaction too large":                           RejectionTooLarge,
+		"16: mandatory-script-verify-flag-failed (Error)": RejectionUnknown,
+		"": RejectionUnknown,
+	}
+
+	for description, expected := range tests {
+		t.Run(description, func(t *testing.T) {
+			assert.Equal(t, expected, ClassifyRejection(description))
+		})
+	}
+}
+
+// TestRejectionKindOf tests the method RejectionKindOf()
+func TestRejectionKindOf(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, RejectionMissingInputs, RejectionKindOf("failure", "Missing inputs"))
+	assert.Equal(t, RejectionKind(""), RejectionKindOf("success", ""))
+	assert.Equal(t, RejectionKind(""), RejectionKindOf("success", "Already known"))
+}
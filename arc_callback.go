@@ -0,0 +1,163 @@
+package minercraft
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/libsv/go-bc"
+	"github.com/tonicpow/go-minercraft/v2/apis/arc"
+)
+
+// maxCallbackBodySize is the max size of a callback body (batched callbacks can be large)
+const maxCallbackBodySize = 10 << 20
+
+// ArcCallbackEvent is a transaction status update received from Arc
+type ArcCallbackEvent struct {
+	BlockHash    string       `json:"blockHash,omitempty"`
+	BlockHeight  int64        `json:"blockHeight,omitempty"`
+	CompetingTxs []string     `json:"competingTxs,omitempty"`
+	ExtraInfo    string       `json:"extraInfo,omitempty"`
+	MerklePath   string       `json:"merklePath,omitempty"` // BUMP hex (BRC-74)
+	Timestamp    time.Time    `json:"timestamp,omitempty"`
+	TxStatus     arc.TxStatus `json:"txStatus,omitempty"`
+	TxID         string       `json:"txid,omitempty"`
+}
+
+// BUMP will parse the merkle path of the event (only set once the transaction is mined)
+func (e *ArcCallbackEvent) BUMP() (*bc.BUMP, error) {
+	if len(e.MerklePath) == 0 {
+		return nil, errors.New("missing merkle path")
+	}
+	return bc.NewBUMPFromStr(e.MerklePath)
+}
+
+// ArcCallbackFunc is called for every event received by the ArcCallbackHandler
+//
+// If an error is returned the callback is answered with a 500, so Arc will send it again.
+type ArcCallbackFunc func(ctx context.Context, event *ArcCallbackEvent) error
+
+// ArcCallbackHandler is an http.Handler receiving the Arc transaction status callbacks
+//
+// Set the CallBackURL of the Transaction to the url of this handler and the CallBackToken
+// to the token of this handler. Batched callbacks (X-CallbackBatch) are sent to the url
+// suffixed with "/batch" (mount the handler on both paths) and are split into one event
+// per transaction, with the same shape as single callbacks.
+type ArcCallbackHandler struct {
+	onEvent ArcCallbackFunc
+	token   string
+}
+
+// NewArcCallbackHandler will create a new handler calling the given function for every event
+//
+// The token must match the CallBackToken used when submitting. The token is required,
+// with an empty token every callback is rejected.
+func NewArcCallbackHandler(token string, onEvent ArcCallbackFunc) *ArcCallbackHandler {
+	return &ArcCallbackHandler{onEvent: onEvent, token: token}
+}
+
+// NewArcCallbackChannelHandler will create a new handler sending every event to the given channel
+//
+// If the channel is full the handler blocks until the event is received or the request is canceled.
+func NewArcCallbackChannelHandler(token string, events chan<- *ArcCallbackEvent) *ArcCallbackHandler {
+	return NewArcCallbackHandler(token, func(ctx context.Context, event *ArcCallbackEvent) error {
+		select {
+		case events <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// ServeHTTP will authorize, parse and deliver the callback
+func (h *ArcCallbackHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !validCallbackToken(req, h.token) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxCallbackBodySize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var events []*ArcCallbackEvent
+	if events, err = ParseArcCallback(body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, event := range events {
+		if err = h.onEvent(req.Context(), event); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// ParseArcCallback will parse the body of an Arc callback, single or batched,
+// into one event per transaction
+func ParseArcCallback(body []byte) ([]*ArcCallbackEvent, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal arc callback: %w", err)
+	}
+
+	var callbacks []*arc.Callback
+	if _, ok := raw["callbacks"]; ok {
+		batch := new(arc.BatchCallback)
+		if err := json.Unmarshal(body, batch); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal arc batch callback: %w", err)
+		}
+		callbacks = batch.Callbacks
+	} else {
+		callback := new(arc.Callback)
+		if err := json.Unmarshal(body, callback); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal arc callback: %w", err)
+		}
+		callbacks = []*arc.Callback{callback}
+	}
+
+	events := make([]*ArcCallbackEvent, 0, len(callbacks))
+	for _, callback := range callbacks {
+		if callback == nil || len(callback.TxID) == 0 {
+			return nil, errors.New("arc callback is missing the txid")
+		}
+		events = append(events, &ArcCallbackEvent{
+			BlockHash:    callback.BlockHash,
+			BlockHeight:  callback.BlockHeight,
+			CompetingTxs: callback.CompetingTxs,
+			ExtraInfo:    callback.ExtraInfo,
+			MerklePath:   callback.MerklePath,
+			Timestamp:    callback.Timestamp,
+			TxStatus:     callback.TxStatus,
+			TxID:         callback.TxID,
+		})
+	}
+	return events, nil
+}
+
+// validCallbackToken will check the bearer token of the callback request (constant time)
+//
+// Without a configured token no request is valid.
+func validCallbackToken(req *http.Request, token string) bool {
+	if len(token) == 0 {
+		return false
+	}
+	expected := []byte("Bearer " + token)
+	return subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), expected) == 1
+}
@@ -0,0 +1,215 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tonicpow/go-minercraft/v2/apis/arc"
+)
+
+const (
+	arcCallbackToken = "callback-token"
+	arcCallbackBody  = `{
+		"timestamp": "2023-08-10T13:49:07.308687569Z",
+		"txid": "` + testTx + `",
+		"txStatus": "MINED",
+		"blockHash": "0000000000000000064cbaac5cedf71a5447771573ba585501952c023873817b",
+		"blockHeight": 814435,
+		"merklePath": "` + beefTestBUMP + `"}`
+	arcCallbackBatchBody = `{"count": 2, "callbacks": [
+		{"txid": "` + testTx + `", "txStatus": "SEEN_ON_NETWORK"},
+		{"txid": "6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0", "txStatus": "DOUBLE_SPEND_ATTEMPTED",
+			"competingTxs": ["3ecead27a44d013ad1aae40038acbb1883ac9242406808bb4667c15b4f164eac"]}]}`
+)
+
+// newArcCallbackRequest will return a new callback request
+func newArcCallbackRequest(body, token string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+// TestArcCallbackHandler tests the ArcCallbackHandler
+func TestArcCallbackHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid callback", func(t *testing.T) {
+		var events []*ArcCallbackEvent
+		handler := NewArcCallbackHandler(arcCallbackToken, func(_ context.Context, event *ArcCallbackEvent) error {
+			events = append(events, event)
+			return nil
+		})
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newArcCallbackRequest(arcCallbackBody, arcCallbackToken))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		require.Len(t, events, 1)
+		assert.Equal(t, testTx, events[0].TxID)
+		assert.Equal(t, arc.Mined, events[0].TxStatus)
+		assert.Equal(t, int64(814435), events[0].BlockHeight)
+		assert.Equal(t, 2023, events[0].Timestamp.Year())
+
+		bump, err := events[0].BUMP()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(814435), bump.BlockHeight)
+	})
+
+	t.Run("batched callback", func(t *testing.T) {
+		var events []*ArcCallbackEvent
+		handler := NewArcCallbackHandler(arcCallbackToken, func(_ context.Context, event *ArcCallbackEvent) error {
+			events = append(events, event)
+			return nil
+		})
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newArcCallbackRequest(arcCallbackBatchBody, arcCallbackToken))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		require.Len(t, events, 2)
+		assert.Equal(t, arc.SeenOnNetwork, events[0].TxStatus)
+		assert.Equal(t, arc.DoubleSpendAttempted, events[1].TxStatus)
+		assert.Equal(t, []string{"3ecead27a44d013ad1aae40038acbb1883ac9242406808bb4667c15b4f164eac"}, events[1].CompetingTxs)
+
+		_, err := events[0].BUMP()
+		require.Error(t, err)
+	})
+
+	t.Run("channel handler", func(t *testing.T) {
+		events := make(chan *ArcCallbackEvent, 1)
+		handler := NewArcCallbackChannelHandler(arcCallbackToken, events)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newArcCallbackRequest(arcCallbackBody, arcCallbackToken))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, testTx, (<-events).TxID)
+	})
+
+	t.Run("channel handler, canceled request", func(t *testing.T) {
+		handler := NewArcCallbackChannelHandler(arcCallbackToken, make(chan *ArcCallbackEvent))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newArcCallbackRequest(arcCallbackBody, arcCallbackToken).WithContext(ctx))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		called := false
+		handler := NewArcCallbackHandler(arcCallbackToken, func(_ context.Context, _ *ArcCallbackEvent) error {
+			called = true
+			return nil
+		})
+
+		for _, token := range []string{"", "wrong-token"} {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newArcCallbackRequest(arcCallbackBody, token))
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		}
+		assert.False(t, called)
+	})
+
+	t.Run("no token configured", func(t *testing.T) {
+		called := false
+		handler := NewArcCallbackHandler("", func(_ context.Context, _ *ArcCallbackEvent) error {
+			called = true
+			return nil
+		})
+
+		for _, token := range []string{"", arcCallbackToken} {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newArcCallbackRequest(arcCallbackBody, token))
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		}
+		assert.False(t, called)
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		handler := NewArcCallbackHandler(arcCallbackToken, nil)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/callback", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		handler := NewArcCallbackHandler(arcCallbackToken, nil)
+
+		for _, body := range []string{"invalid", `{"txStatus": "MINED"}`, `{"callbacks": "invalid"}`} {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newArcCallbackRequest(body, arcCallbackToken))
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		handler := NewArcCallbackHandler(arcCallbackToken, func(_ context.Context, _ *ArcCallbackEvent) error {
+			return errors.New("failed")
+		})
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newArcCallbackRequest(arcCallbackBody, arcCallbackToken))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+// ExampleNewArcCallbackChannelHandler example using NewArcCallbackChannelHandler()
+func ExampleNewArcCallbackChannelHandler() {
+	events := make(chan *ArcCallbackEvent, 10)
+	handler := NewArcCallbackChannelHandler(arcCallbackToken, events)
+
+	// Use the handler in your server: http.Handle("/callback", handler)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newArcCallbackRequest(arcCallbackBody, arcCallbackToken))
+
+	event := <-events
+	fmt.Printf("tx status: %s", event.TxStatus)
+	// Output:tx status: MINED
+}
+
+// TestArcCallbackHandler_Batch tests single and batched callbacks are received the same way
+func TestArcCallbackHandler_Batch(t *testing.T) {
+	t.Parallel()
+
+	events := make(chan *ArcCallbackEvent, 10)
+	handler := NewArcCallbackChannelHandler(arcCallbackToken, events)
+
+	mux := http.NewServeMux()
+	mux.Handle("/callback", handler)
+	mux.Handle("/callback/batch", handler)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	post := func(url, body string) {
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+arcCallbackToken)
+
+		var resp *http.Response
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	single := `{"txid": "` + testTx + `", "txStatus": "SEEN_ON_NETWORK"}`
+	post(server.URL+"/callback", single)
+	post(
+		arcCallbackURL(&Transaction{CallBackURL: server.URL + "/callback", CallbackBatch: true}),
+		`{"count": 1, "callbacks": [`+single+`]}`,
+	)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, <-events, <-events)
+}
@@ -0,0 +1,130 @@
+package minercraft
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ArcStatusError is an Arc error status code, it's used as a sentinel error
+// to check an ArcErrorResponse using errors.Is:
+//
+//	if errors.Is(err, minercraft.ErrArcFeeTooLow) {
+//		// add more fees
+//	}
+type ArcStatusError struct {
+	Code      int    // Arc status code
+	Message   string // Short description of the status code
+	Retryable bool   // If true, the same request can be sent again later
+}
+
+// Error returns the error message related to the ArcStatusError
+func (e *ArcStatusError) Error() string {
+	return fmt.Sprintf("arc error %d: %s", e.Code, e.Message)
+}
+
+// Arc error status codes
+//
+// Specs: https://bitcoin-sv.github.io/arc/#/errors
+var (
+	// ErrArcBadRequest is returned when the request is invalid
+	ErrArcBadRequest = &ArcStatusError{Code: http.StatusBadRequest, Message: "bad request"}
+	// ErrArcUnauthorized is returned when the token is missing or invalid
+	ErrArcUnauthorized = &ArcStatusError{Code: http.StatusUnauthorized, Message: "unauthorized"}
+	// ErrArcNotFound is returned when the resource (tx) was not found
+	ErrArcNotFound = &ArcStatusError{Code: http.StatusNotFound, Message: "not found"}
+	// ErrArcGeneric is returned for a generic error
+	ErrArcGeneric = &ArcStatusError{Code: http.StatusConflict, Message: "generic error"}
+	// ErrArcUnprocessableEntity is returned when the request could not be processed
+	ErrArcUnprocessableEntity = &ArcStatusError{Code: http.StatusUnprocessableEntity, Message: "unprocessable entity"}
+	// ErrArcTooManyRequests is returned when the rate limit is reached
+	ErrArcTooManyRequests = &ArcStatusError{Code: http.StatusTooManyRequests, Message: "too many requests", Retryable: true}
+	// ErrArcNotExtendedFormat is returned when the parent transactions are unknown and the tx is not in Extended Format
+	ErrArcNotExtendedFormat = &ArcStatusError{Code: 460, Message: "not extended format"}
+	// ErrArcUnlockingScripts is returned when the unlocking scripts are invalid
+	ErrArcUnlockingScripts = &ArcStatusError{Code: 461, Message: "malformed unlocking scripts"}
+	// ErrArcInputs is returned when the inputs are invalid (already spent or missing)
+	ErrArcInputs = &ArcStatusError{Code: 462, Message: "invalid inputs"}
+	// ErrArcMalformed is returned when the transaction is malformed
+	ErrArcMalformed = &ArcStatusError{Code: 463, Message: "malformed transaction"}
+	// ErrArcOutputs is returned when the outputs are invalid
+	ErrArcOutputs = &ArcStatusError{Code: 464, Message: "invalid outputs"}
+	// ErrArcFeeTooLow is returned when the fee is too low
+	ErrArcFeeTooLow = &ArcStatusError{Code: 465, Message: "fee too low"}
+	// ErrArcConflict is returned when the transaction conflicts with another transaction (double spend)
+	ErrArcConflict = &ArcStatusError{Code: 466, Message: "conflicting transaction found"}
+	// ErrArcMinedAncestorsNotFound is returned when the BEEF mined ancestors are not found
+	ErrArcMinedAncestorsNotFound = &ArcStatusError{Code: 467, Message: "mined ancestors not found"}
+	// ErrArcCalculatingMerkleRoots is returned when the BEEF merkle roots could not be calculated
+	ErrArcCalculatingMerkleRoots = &ArcStatusError{Code: 468, Message: "invalid BUMPs"}
+	// ErrArcValidatingMerkleRoots is returned when the BEEF merkle roots could not be validated (yet)
+	ErrArcValidatingMerkleRoots = &ArcStatusError{Code: 469, Message: "merkle roots validation failed", Retryable: true}
+	// ErrArcFrozenPolicy is returned when the inputs are frozen (policy)
+	ErrArcFrozenPolicy = &ArcStatusError{Code: 471, Message: "input frozen (policy)"}
+	// ErrArcFrozenConsensus is returned when the inputs are frozen (consensus)
+	ErrArcFrozenConsensus = &ArcStatusError{Code: 472, Message: "input frozen (consensus)"}
+	// ErrArcCumulativeFeeTooLow is returned when the cumulative fee of the unmined ancestors is too low
+	ErrArcCumulativeFeeTooLow = &ArcStatusError{Code: 473, Message: "cumulative fee too low"}
+)
+
+// arcStatusErrors is the list of known Arc status codes
+var arcStatusErrors = map[int]*ArcStatusError{}
+
+func init() {
+	for _, e := range []*ArcStatusError{
+		ErrArcBadRequest, ErrArcUnauthorized, ErrArcNotFound, ErrArcGeneric,
+		ErrArcUnprocessableEntity, ErrArcTooManyRequests, ErrArcNotExtendedFormat,
+		ErrArcUnlockingScripts, ErrArcInputs, ErrArcMalformed, ErrArcOutputs,
+		ErrArcFeeTooLow, ErrArcConflict, ErrArcMinedAncestorsNotFound,
+		ErrArcCalculatingMerkleRoots, ErrArcValidatingMerkleRoots, ErrArcFrozenPolicy,
+		ErrArcFrozenConsensus, ErrArcCumulativeFeeTooLow,
+	} {
+		arcStatusErrors[e.Code] = e
+	}
+}
+
+// ArcErrorResponse is the response returned from Arc on error (RFC 7807)
+//
+// It can be checked against the Arc status codes using errors.Is (ex: ErrArcFeeTooLow)
+// or converted using errors.As:
+//
+//	var errResp ArcErrorResponse
+//	if errors.As(err, &errResp) {
+//		fmt.Println(errResp.TxID, errResp.ExtraInfo)
+//	}
+type ArcErrorResponse struct {
+	Detail    string `json:"detail"`
+	ExtraInfo string `json:"extraInfo,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Status    int    `json:"status"`
+	Title     string `json:"title"`
+	TxID      string `json:"txid,omitempty"`
+	Type      string `json:"type"`
+}
+
+// Error defines the ArcErrorResponse as an error
+func (e ArcErrorResponse) Error() string {
+	return fmt.Sprintf("status: %d \n title: %s \n detail: %s \n txid: %s \n extraInfo: %s",
+		e.Status, e.Title, e.Detail, e.TxID, e.ExtraInfo)
+}
+
+// Is allows the error to be checked against the Arc status codes (ex: ErrArcFeeTooLow)
+func (e ArcErrorResponse) Is(target error) bool {
+	statusErr, ok := target.(*ArcStatusError)
+	return ok && statusErr.Code == e.Status
+}
+
+// StatusError will return the known Arc status code of the error (nil if unknown)
+func (e ArcErrorResponse) StatusError() *ArcStatusError {
+	return arcStatusErrors[e.Status]
+}
+
+// isRetryableArcStatus will return true if the status code is retryable with Arc
+func isRetryableArcStatus(status int) bool {
+	if status >= 500 && status <= 599 {
+		return true
+	}
+	if statusErr, ok := arcStatusErrors[status]; ok {
+		return statusErr.Retryable
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockHTTPArcError will return a mock client answering with the given status and body
+func newMockHTTPArcError(statusCode int, body string) *MockClient {
+	return &MockClient{MockDo: func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+		}, nil
+	}}
+}
+
+// TestArcErrorResponse tests the Arc error responses
+func TestArcErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fee too low", func(t *testing.T) {
+		client := newTestArcClient(newMockHTTPArcError(465, `{
+			"type": "https://bitcoin-sv.github.io/arc/#/errors?id=_465",
+			"title": "Fee too low",
+			"status": 465,
+			"detail": "The fees are too low",
+			"txid": "`+testTx+`",
+			"extraInfo": "fee 10 < 50"}`))
+
+		_, err := client.SubmitTransaction(
+			context.Background(), client.MinerByName(MinerTaal), &Transaction{RawTx: submitTestExampleTx},
+		)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrArcFeeTooLow)
+		assert.NotErrorIs(t, err, ErrArcBadRequest)
+		assert.False(t, IsRetryable(err))
+
+		var errResp ArcErrorResponse
+		require.ErrorAs(t, err, &errResp)
+		assert.Equal(t, testTx, errResp.TxID)
+		assert.Equal(t, "fee 10 < 50", errResp.ExtraInfo)
+		assert.Equal(t, ErrArcFeeTooLow, errResp.StatusError())
+		assert.Contains(t, errResp.Error(), "fee 10 < 50")
+	})
+
+	t.Run("retryable status codes", func(t *testing.T) {
+		for _, statusCode := range []int{http.StatusTooManyRequests, 469, http.StatusBadGateway} {
+			client := newTestArcClient(newMockHTTPArcError(statusCode, `{"title": "error"}`))
+
+			_, err := client.QueryTransaction(context.Background(), client.MinerByName(MinerTaal), testTx)
+			require.Error(t, err)
+			assert.True(t, IsRetryable(err), statusCode)
+
+			var errResp ArcErrorResponse
+			require.ErrorAs(t, err, &errResp)
+			assert.Equal(t, statusCode, errResp.Status)
+		}
+	})
+
+	t.Run("sentinel through retryable", func(t *testing.T) {
+		client := newTestArcClient(newMockHTTPArcError(469, `{"status": 469, "title": "Merkle roots validation failed"}`))
+
+		_, err := client.SubmitTransaction(
+			context.Background(), client.MinerByName(MinerTaal), &Transaction{RawTx: submitTestExampleTx},
+		)
+		assert.ErrorIs(t, err, ErrArcValidatingMerkleRoots)
+		assert.True(t, IsRetryable(err))
+	})
+
+	t.Run("not json", func(t *testing.T) {
+		client := newTestArcClient(newMockHTTPArcError(http.StatusUnauthorized, "unauthorized"))
+
+		_, err := client.FeeQuote(context.Background(), client.MinerByName(MinerTaal))
+		require.ErrorIs(t, err, ErrArcUnauthorized)
+
+		var errResp ArcErrorResponse
+		require.ErrorAs(t, err, &errResp)
+		assert.Equal(t, "unauthorized", errResp.Detail)
+		assert.Equal(t, "Unauthorized", errResp.Title)
+	})
+
+	t.Run("unknown status code", func(t *testing.T) {
+		err := arcRequestError(499, nil)
+		assert.False(t, IsRetryable(err))
+
+		var errResp ArcErrorResponse
+		require.ErrorAs(t, err, &errResp)
+		assert.Nil(t, errResp.StatusError())
+	})
+
+	t.Run("mapi errors are unchanged", func(t *testing.T) {
+		client := newTestClient(newMockHTTPArcError(http.StatusBadRequest, `{"title": "bad request", "status": 400}`))
+
+		_, err := client.FeeQuote(context.Background(), client.MinerByName(MinerTaal))
+		var errResp ErrorResponse
+		require.ErrorAs(t, err, &errResp)
+		assert.False(t, errors.Is(err, ErrArcBadRequest))
+	})
+}
@@ -0,0 +1,297 @@
+package minercraft
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/libsv/go-bc"
+	"github.com/libsv/go-bt/v2"
+	"github.com/tonicpow/go-minercraft/v2/apis/arc"
+)
+
+// beefVersion is the BEEF version marker (0100BEEF) as a little endian uint32
+const beefVersion uint32 = 4022206465
+
+// contentTypeOctetStream is the content type used when submitting binary payloads
+const contentTypeOctetStream = "application/octet-stream"
+
+// ErrBEEFNotSupported is returned when submitting a BEEF package to mAPI
+var ErrBEEFNotSupported = errors.New("BEEF is only supported by Arc")
+
+// BEEFTx is a transaction inside a BEEF package
+//
+// If HasBUMP is true, BUMPIndex is the index of the BUMP (merkle path) in the package
+// proving the transaction is mined.
+type BEEFTx struct {
+	Tx        *bt.Tx
+	HasBUMP   bool
+	BUMPIndex int
+}
+
+// BEEF is a transaction package in Background Evaluation Extended Format
+//
+// The transactions are ordered with parents before children, the last transaction
+// is the new transaction being submitted. Unconfirmed ancestors are included in full,
+// mined ancestors reference their BUMP.
+//
+// Specs: https://brc.dev/62
+type BEEF struct {
+	BUMPs        []*bc.BUMP
+	Transactions []*BEEFTx
+}
+
+// NewBEEF will create a new BEEF package and validate it
+func NewBEEF(bumps []*bc.BUMP, txs ...*BEEFTx) (*BEEF, error) {
+	beef := &BEEF{BUMPs: bumps, Transactions: txs}
+	if err := beef.validate(); err != nil {
+		return nil, err
+	}
+	return beef, nil
+}
+
+// NewBEEFFromBytes will parse a BEEF package from its binary format
+func NewBEEFFromBytes(b []byte) (*BEEF, error) {
+	if len(b) < 4 || binary.LittleEndian.Uint32(b[:4]) != beefVersion {
+		return nil, errors.New("invalid BEEF version")
+	}
+	offset := 4
+
+	beef := new(BEEF)
+
+	// Read the BUMPs
+	nBUMPs, size, err := readBEEFVarInt(b, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse BEEF bump count: %w", err)
+	}
+	offset += size
+	for i := uint64(0); i < uint64(nBUMPs); i++ {
+		bump, read, err := readBEEFBUMP(b[offset:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse BEEF bump %d: %w", i, err)
+		}
+		offset += read
+		beef.BUMPs = append(beef.BUMPs, bump)
+	}
+
+	// Read the transactions
+	if offset >= len(b) {
+		return nil, errors.New("BEEF has no transactions")
+	}
+	nTxs, size, err := readBEEFVarInt(b, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse BEEF tx count: %w", err)
+	}
+	offset += size
+	for i := uint64(0); i < uint64(nTxs); i++ {
+		tx, read, err := readBEEFTx(b[offset:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse BEEF tx %d: %w", i, err)
+		}
+		offset += read
+		if offset >= len(b) {
+			return nil, fmt.Errorf("failed to parse BEEF tx %d: missing bump flag", i)
+		}
+
+		beefTx := &BEEFTx{Tx: tx, HasBUMP: b[offset] == 1}
+		offset++
+		if beefTx.HasBUMP {
+			index, indexSize, err := readBEEFVarInt(b, offset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse BEEF tx %d bump index: %w", i, err)
+			}
+			offset += indexSize
+			beefTx.BUMPIndex = int(index)
+		}
+		beef.Transactions = append(beef.Transactions, beefTx)
+	}
+
+	if err := beef.validate(); err != nil {
+		return nil, err
+	}
+	return beef, nil
+}
+
+// readBEEFVarInt will read the VarInt at the offset, checking the bytes are available
+func readBEEFVarInt(b []byte, offset int) (bt.VarInt, int, error) {
+	if offset >= len(b) {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	size := 1
+	switch b[offset] {
+	case 0xfd:
+		size = 3
+	case 0xfe:
+		size = 5
+	case 0xff:
+		size = 9
+	}
+	if len(b)-offset < size {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	value, _ := bt.NewVarIntFromBytes(b[offset:])
+	return value, size, nil
+}
+
+// readBEEFBUMP will read a BUMP from the stream (the parser panics on truncated input)
+func readBEEFBUMP(b []byte) (bump *bc.BUMP, read int, err error) {
+	defer recoverTruncatedBEEF(&err)
+	if len(b) == 0 {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	return bc.NewBUMPFromStream(b)
+}
+
+// readBEEFTx will read a transaction from the stream (the parser panics on truncated input)
+func readBEEFTx(b []byte) (tx *bt.Tx, read int, err error) {
+	defer recoverTruncatedBEEF(&err)
+	if len(b) == 0 {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	return bt.NewTxFromStream(b)
+}
+
+// recoverTruncatedBEEF will convert a panic of a stream parser into an error
+func recoverTruncatedBEEF(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%w: %v", io.ErrUnexpectedEOF, r)
+	}
+}
+
+// NewBEEFFromString will parse a BEEF package from a hex string
+func NewBEEFFromString(str string) (*BEEF, error) {
+	b, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, err
+	}
+	return NewBEEFFromBytes(b)
+}
+
+// Bytes will serialize the BEEF package into its binary format
+func (b *BEEF) Bytes() ([]byte, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 4, 1024)
+	binary.LittleEndian.PutUint32(data, beefVersion)
+
+	data = append(data, bt.VarInt(uint64(len(b.BUMPs))).Bytes()...)
+	for _, bump := range b.BUMPs {
+		bumpBytes, err := bump.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, bumpBytes...)
+	}
+
+	data = append(data, bt.VarInt(uint64(len(b.Transactions))).Bytes()...)
+	for _, beefTx := range b.Transactions {
+		data = append(data, beefTx.Tx.Bytes()...)
+		if !beefTx.HasBUMP {
+			data = append(data, 0)
+			continue
+		}
+		data = append(data, 1)
+		data = append(data, bt.VarInt(uint64(beefTx.BUMPIndex)).Bytes()...)
+	}
+
+	return data, nil
+}
+
+// String will serialize the BEEF package into a hex string
+func (b *BEEF) String() (string, error) {
+	data, err := b.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// SubjectTx will return the transaction being submitted (the last transaction)
+func (b *BEEF) SubjectTx() *bt.Tx {
+	if len(b.Transactions) == 0 {
+		return nil
+	}
+	return b.Transactions[len(b.Transactions)-1].Tx
+}
+
+// validate will check the BEEF package is complete
+func (b *BEEF) validate() error {
+	if len(b.Transactions) == 0 {
+		return errors.New("BEEF has no transactions")
+	}
+	for i, beefTx := range b.Transactions {
+		if beefTx == nil || beefTx.Tx == nil {
+			return fmt.Errorf("BEEF tx %d is nil", i)
+		}
+		if beefTx.HasBUMP && (beefTx.BUMPIndex < 0 || beefTx.BUMPIndex >= len(b.BUMPs)) {
+			return fmt.Errorf("BEEF tx %d has an invalid bump index: %d", i, beefTx.BUMPIndex)
+		}
+	}
+	return nil
+}
+
+// NewBEEFTransaction will create a Transaction from a BEEF package to submit to Arc
+//
+// Specs: https://brc.dev/62
+func NewBEEFTransaction(beef *BEEF) (*Transaction, error) {
+	if beef == nil {
+		return nil, errors.New("beef was nil")
+	}
+	if err := beef.validate(); err != nil {
+		return nil, err
+	}
+	return &Transaction{BEEF: beef}, nil
+}
+
+// parseArcSubmitTxModels will parse the Arc submit response, which is a single object
+// for a transaction or an array of objects for a BEEF package
+func parseArcSubmitTxModels(body []byte) ([]arc.SubmitTxModel, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var models []arc.SubmitTxModel
+		if err := json.Unmarshal(trimmed, &models); err != nil {
+			return nil, err
+		}
+		if len(models) == 0 {
+			return nil, errors.New("empty submit response")
+		}
+		return models, nil
+	}
+
+	model := arc.SubmitTxModel{}
+	if err := json.Unmarshal(body, &model); err != nil {
+		return nil, err
+	}
+	return []arc.SubmitTxModel{model}, nil
+}
+
+// arcSubjectTxModel will return the result of the submitted transaction, for a BEEF
+// package this is the result matching the subject tx (defaults to the last result)
+func arcSubjectTxModel(models []arc.SubmitTxModel, tx *Transaction) *arc.SubmitTxModel {
+	if tx.BEEF != nil {
+		if subject := tx.BEEF.SubjectTx(); subject != nil {
+			txID := subject.TxID()
+			for i := range models {
+				if models[i].TxID == txID {
+					return &models[i]
+				}
+			}
+		}
+	}
+	return &models[len(models)-1]
+}
+
+// arcPackageResults will convert the Arc results into unified transactions
+func arcPackageResults(models []arc.SubmitTxModel) []UnifiedTx {
+	results := make([]UnifiedTx, 0, len(models))
+	for _, model := range models {
+		results = append(results, convertArcSubmitTxModelToUnifiedTx(model))
+	}
+	return results
+}
@@ -0,0 +1,222 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/libsv/go-bc"
+	"github.com/libsv/go-bt/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tonicpow/go-minercraft/v2/apis/arc"
+)
+
+const beefTestBUMP = "fe636d0c0007021400fe507c0c7aa754cef1f7889d5fd395cf1f785dd7de98eed895dbedfe4e5bc70d1502ac4e164f5bc16746bb0868404292ac8318bbac3800e4aad13a014da427adce3e010b00bc4ff395efd11719b277694cface5aa50d085a0bb81f613f70313acd28cf4557010400574b2d9142b8d28b61d88e3b2c3f44d858411356b49a28a4643b6d1a6a092a5201030051a05fc84d531b5d250c23f4f886f6812f9fe3f402d61607f977b4ecd2701c19010000fd781529d58fc2523cf396a7f25440b409857e7e221766c57214b1d38c7b481f01010062f542f45ea3660f86c013ced80534cb5fd4c19d66c56e7e8c5d4bf2d40acc5e010100b121e91836fd7cd5102b654e9f72f3cf6fdbfd0b161c53a9c54b12c841126331"
+
+// newTestBEEF will return a BEEF package with a mined parent and an unconfirmed child
+func newTestBEEF(t *testing.T) *BEEF {
+	bump, err := bc.NewBUMPFromStr(beefTestBUMP)
+	require.NoError(t, err)
+
+	var parent, child *bt.Tx
+	parent, err = bt.NewTxFromString(submitTestExampleTx)
+	require.NoError(t, err)
+	child, err = bt.NewTxFromString(rawTx)
+	require.NoError(t, err)
+
+	var beef *BEEF
+	beef, err = NewBEEF(
+		[]*bc.BUMP{bump},
+		&BEEFTx{Tx: parent, HasBUMP: true, BUMPIndex: 0},
+		&BEEFTx{Tx: child},
+	)
+	require.NoError(t, err)
+	return beef
+}
+
+// mockHTTPArcPackage for mocking an Arc BEEF submission
+type mockHTTPArcPackage struct {
+	body        []byte
+	contentType string
+	response    string
+}
+
+// Do is a mock http request
+func (m *mockHTTPArcPackage) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		m.body, _ = io.ReadAll(req.Body)
+	}
+	m.contentType = req.Header.Get("Content-Type")
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(m.response)),
+	}, nil
+}
+
+// TestBEEF tests the BEEF serialization
+func TestBEEF(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bytes round trip", func(t *testing.T) {
+		beef := newTestBEEF(t)
+
+		b, err := beef.Bytes()
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x00, 0xbe, 0xef}, b[:4])
+
+		var parsed *BEEF
+		parsed, err = NewBEEFFromBytes(b)
+		require.NoError(t, err)
+		require.Len(t, parsed.BUMPs, 1)
+		require.Len(t, parsed.Transactions, 2)
+		assert.True(t, parsed.Transactions[0].HasBUMP)
+		assert.Equal(t, 0, parsed.Transactions[0].BUMPIndex)
+		assert.False(t, parsed.Transactions[1].HasBUMP)
+		assert.Equal(t, beef.SubjectTx().TxID(), parsed.SubjectTx().TxID())
+	})
+
+	t.Run("string round trip", func(t *testing.T) {
+		str, err := newTestBEEF(t).String()
+		require.NoError(t, err)
+
+		var parsed *BEEF
+		parsed, err = NewBEEFFromString(str)
+		require.NoError(t, err)
+
+		var again string
+		again, err = parsed.String()
+		require.NoError(t, err)
+		assert.Equal(t, str, again)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, err := NewBEEFFromBytes([]byte{0x01, 0x00, 0x00, 0x00, 0x00})
+		require.Error(t, err)
+	})
+
+	t.Run("truncated input", func(t *testing.T) {
+		for _, str := range []string{
+			"0100beef",
+			"0100beefff",
+			"0100beeffd01",
+			"0100beef00",
+			"0100beef0001",
+		} {
+			_, err := NewBEEFFromString(str)
+			assert.Error(t, err, str)
+		}
+	})
+
+	t.Run("truncated package", func(t *testing.T) {
+		b, err := newTestBEEF(t).Bytes()
+		require.NoError(t, err)
+
+		// Every prefix of a valid package returns an error (and never panics)
+		for i := 0; i < len(b); i++ {
+			_, err = NewBEEFFromBytes(b[:i])
+			assert.Error(t, err, i)
+		}
+	})
+
+	t.Run("invalid hex", func(t *testing.T) {
+		_, err := NewBEEFFromString("zz")
+		require.Error(t, err)
+	})
+
+	t.Run("no transactions", func(t *testing.T) {
+		_, err := NewBEEF(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid bump index", func(t *testing.T) {
+		tx, err := bt.NewTxFromString(rawTx)
+		require.NoError(t, err)
+
+		_, err = NewBEEF(nil, &BEEFTx{Tx: tx, HasBUMP: true, BUMPIndex: 1})
+		require.Error(t, err)
+	})
+
+	t.Run("nil beef transaction", func(t *testing.T) {
+		_, err := NewBEEFTransaction(nil)
+		require.Error(t, err)
+	})
+}
+
+// TestClient_SubmitTransaction_BEEF tests submitting a BEEF package
+func TestClient_SubmitTransaction_BEEF(t *testing.T) {
+	t.Parallel()
+
+	t.Run("arc receives the beef package", func(t *testing.T) {
+		beef := newTestBEEF(t)
+		transaction, err := NewBEEFTransaction(beef)
+		require.NoError(t, err)
+
+		parentID := beef.Transactions[0].Tx.TxID()
+		childID := beef.SubjectTx().TxID()
+		httpClient := &mockHTTPArcPackage{response: `[
+			{"status":200,"title":"OK","txStatus":"MINED","txid":"` + parentID + `"},
+			{"status":200,"title":"OK","txStatus":"SEEN_ON_NETWORK","txid":"` + childID + `"}]`}
+		client := newTestArcClient(httpClient)
+
+		var response *SubmitTransactionResponse
+		response, err = client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), transaction)
+		require.NoError(t, err)
+		require.NotNil(t, response)
+
+		expected, _ := beef.Bytes()
+		assert.Equal(t, expected, httpClient.body)
+		assert.Equal(t, contentTypeOctetStream, httpClient.contentType)
+
+		assert.Equal(t, childID, response.Results.TxID)
+		assert.Equal(t, arc.SeenOnNetwork, response.Results.TxStatus)
+		require.Len(t, response.Results.PackageResults, 2)
+		assert.Equal(t, parentID, response.Results.PackageResults[0].TxID)
+		assert.Equal(t, arc.Mined, response.Results.PackageResults[0].TxStatus)
+	})
+
+	t.Run("arc returns a single result", func(t *testing.T) {
+		transaction, err := NewBEEFTransaction(newTestBEEF(t))
+		require.NoError(t, err)
+
+		httpClient := &mockHTTPArcPackage{
+			response: `{"status":200,"title":"OK","txStatus":"SEEN_ON_NETWORK","txid":"` + testTx + `"}`,
+		}
+		client := newTestArcClient(httpClient)
+
+		var response *SubmitTransactionResponse
+		response, err = client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), transaction)
+		require.NoError(t, err)
+		assert.Equal(t, testTx, response.Results.TxID)
+		assert.Len(t, response.Results.PackageResults, 1)
+	})
+
+	t.Run("raw tx still uses json", func(t *testing.T) {
+		httpClient := &mockHTTPArcPackage{
+			response: `{"status":200,"title":"OK","txStatus":"SEEN_ON_NETWORK","txid":"` + testTx + `"}`,
+		}
+		client := newTestArcClient(httpClient)
+
+		response, err := client.SubmitTransaction(
+			context.Background(), client.MinerByName(MinerGorillaPool), &Transaction{RawTx: rawTx},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", httpClient.contentType)
+		assert.Nil(t, response.Results.PackageResults)
+	})
+
+	t.Run("mapi does not support beef", func(t *testing.T) {
+		transaction, err := NewBEEFTransaction(newTestBEEF(t))
+		require.NoError(t, err)
+
+		client := newTestClient(&mockHTTPValidSubmission{})
+		_, err = client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), transaction)
+		require.ErrorIs(t, err, ErrBEEFNotSupported)
+
+		_, err = client.SubmitTransactions(context.Background(), client.MinerByName(MinerGorillaPool), []Transaction{*transaction})
+		require.ErrorIs(t, err, ErrBEEFNotSupported)
+	})
+}
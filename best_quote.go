@@ -9,23 +9,34 @@ import (
 //
 // Note: this might return different results each time if miners have the same rates as
 // it's a race condition on which results come back first
+//
+// If the health monitor is running, the quotes of the healthy miners are used instead
+// of requesting them (see StartHealthMonitor).
 func (c *Client) BestQuote(ctx context.Context, feeCategory, feeType string) (*FeeQuoteResponse, error) {
 
-	// Best rate & quote
-	var bestRate uint64
-	var bestQuote FeeQuoteResponse
+	// Use the quotes of the health monitor (if running)
+	if quotes := c.monitoredQuotes(); len(quotes) > 0 {
+		return bestFeeQuote(quotes, feeCategory, feeType, nil)
+	}
+
+	// Skip the miners with an open circuit breaker
+	allMiners := c.minersSnapshot()
+	miners := c.availableMiners(allMiners, "")
+	if len(miners) == 0 && len(allMiners) > 0 {
+		return nil, ErrRetryable{err: ErrCircuitOpen}
+	}
 
 	// The channel for the internal results
-	resultsChannel := make(chan *internalResult, len(c.miners))
+	resultsChannel := make(chan *internalResult, len(miners))
 
 	// Loop each miner (break into a Go routine for each quote request)
 	var wg sync.WaitGroup
-	for _, miner := range c.miners {
+	for _, miner := range miners {
 		wg.Add(1)
 		go func(ctx context.Context, wg *sync.WaitGroup, client *Client,
 			miner *Miner, resultsChannel chan *internalResult) {
 			defer wg.Done()
-			resultsChannel <- getQuote(ctx, client, miner, mAPIRouteFeeQuote)
+			resultsChannel <- getQuote(ctx, client, miner, FeeQuote)
 		}(ctx, &wg, c, miner, resultsChannel)
 	}
 
@@ -33,9 +44,8 @@ func (c *Client) BestQuote(ctx context.Context, feeCategory, feeType string) (*F
 	wg.Wait()
 	close(resultsChannel)
 
-	// Loop the results of the channel
-	var testRate uint64
-	var quoteFound bool
+	// Parse the results of the channel
+	var quotes []*FeeQuoteResponse
 	var lastErr error
 	for result := range resultsChannel {
 
@@ -46,15 +56,35 @@ func (c *Client) BestQuote(ctx context.Context, feeCategory, feeType string) (*F
 		}
 
 		// Parse the response
-		var quote FeeQuoteResponse
-		if quote, lastErr = result.parseFeeQuote(); lastErr != nil {
+		quote, err := result.parseFeeQuote()
+		if err != nil {
+			lastErr = err
 			continue
 		}
+		quotes = append(quotes, &quote)
+	}
+
+	return bestFeeQuote(quotes, feeCategory, feeType, lastErr)
+}
+
+// bestFeeQuote will return the quote with the best rate, or the last error if no rate was found
+func bestFeeQuote(quotes []*FeeQuoteResponse, feeCategory, feeType string,
+	lastErr error) (*FeeQuoteResponse, error) {
+
+	// Best rate & quote
+	var bestRate uint64
+	var bestQuote FeeQuoteResponse
+
+	var testRate uint64
+	var quoteFound bool
+	for _, quote := range quotes {
 
 		// Get a test rate
-		if testRate, lastErr = quote.Quote.CalculateFee(
+		var err error
+		if testRate, err = quote.Quote.CalculateFee(
 			feeCategory, feeType, 1000,
-		); lastErr != nil {
+		); err != nil {
+			lastErr = err
 			continue
 		}
 
@@ -62,7 +92,7 @@ func (c *Client) BestQuote(ctx context.Context, feeCategory, feeType string) (*F
 		quoteFound = true
 		if bestRate == 0 || testRate < bestRate {
 			bestRate = testRate
-			bestQuote = quote
+			bestQuote = *quote
 		}
 	}
 
@@ -255,6 +255,34 @@ func TestClient_BestQuote(t *testing.T) {
 	})
 }
 
+// TestClient_BestQuote_Arc tests the method BestQuote() using Arc
+func TestClient_BestQuote_Arc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get a valid best quote", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPValidArcFeeQuote{})
+
+		response, err := client.BestQuote(context.Background(), mapi.FeeCategoryMining, mapi.FeeTypeStandard)
+		require.NoError(t, err)
+		require.NotNil(t, response)
+
+		assert.Equal(t, MinerGorillaPool, response.Miner.Name)
+		assert.Equal(t, Arc, response.APIType)
+
+		var fee uint64
+		fee, err = response.Quote.CalculateFee(mapi.FeeCategoryMining, mapi.FeeTypeStandard, 1000)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), fee)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPBadRequest{})
+		response, err := client.BestQuote(context.Background(), mapi.FeeCategoryMining, mapi.FeeTypeData)
+		require.Error(t, err)
+		require.Nil(t, response)
+	})
+}
+
 // ExampleClient_BestQuote example using BestQuote()
 func ExampleClient_BestQuote() {
 	// Create a client (using a test client vs NewClient())
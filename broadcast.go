@@ -0,0 +1,130 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tonicpow/go-minercraft/v2/apis/arc"
+)
+
+// ErrQuorumNotReached is returned by Broadcast when not enough miners accepted the transaction
+var ErrQuorumNotReached = errors.New("broadcast quorum not reached")
+
+// BroadcastOptions are the options of Broadcast
+type BroadcastOptions struct {
+	CancelOnQuorum bool                       // Cancel the pending submissions once the quorum is reached
+	Miners         []*Miner                   // Miners to submit to (defaults to all the client miners)
+	Quorum         int                        // Number of miners that must accept the transaction (defaults to all the miners)
+	SubmitOpts     []SubmitTransactionOptFunc // Options used for every submission
+}
+
+// BroadcastResult is the result of the submission to one miner
+type BroadcastResult struct {
+	Accepted bool                       `json:"accepted"`
+	Error    error                      `json:"error,omitempty"`
+	Miner    *Miner                     `json:"miner"`
+	Response *SubmitTransactionResponse `json:"response,omitempty"`
+}
+
+// BroadcastResponse is the response of Broadcast
+type BroadcastResponse struct {
+	Accepted      int                `json:"accepted"`      // Number of miners that accepted the transaction
+	Quorum        int                `json:"quorum"`        // Number of miners that had to accept the transaction
+	QuorumReached bool               `json:"quorumReached"` // True if Accepted >= Quorum
+	Results       []*BroadcastResult `json:"results"`       // Results in the same order as the miners
+}
+
+// Broadcast will submit the transaction to all (or the given) miners concurrently
+//
+// The transaction is broadcast once the quorum of miners accepted it: mAPI returned a success,
+// Arc returned a known status that is not a failure, or the transaction was already known (see
+// WithIdempotentSubmit). If the quorum is not reached, the response is returned along with
+// ErrQuorumNotReached. With CancelOnQuorum, the pending submissions are canceled once the
+// quorum is reached (their result has the context error). Miners with an open circuit
+// breaker are skipped (their result has ErrCircuitOpen).
+func (c *Client) Broadcast(ctx context.Context, tx *Transaction, opts *BroadcastOptions) (*BroadcastResponse, error) {
+
+	// Make sure we have a valid transaction
+	if tx == nil {
+		return nil, errors.New("transaction was nil")
+	}
+
+	if opts == nil {
+		opts = &BroadcastOptions{}
+	}
+
+	miners := opts.Miners
+	if len(miners) == 0 {
+		miners = c.minersSnapshot()
+	}
+	if len(miners) == 0 {
+		return nil, errors.New("no miners to broadcast to")
+	}
+
+	quorum := opts.Quorum
+	if quorum <= 0 {
+		quorum = len(miners)
+	}
+	if quorum > len(miners) {
+		return nil, fmt.Errorf("quorum of %d is more than the %d miners", quorum, len(miners))
+	}
+
+	response := &BroadcastResponse{
+		Quorum:  quorum,
+		Results: make([]*BroadcastResult, len(miners)),
+	}
+
+	broadcastCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Loop each miner (break into a Go routine for each submission)
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for i, miner := range miners {
+		wg.Add(1)
+		go func(i int, miner *Miner) {
+			defer wg.Done()
+			result := &BroadcastResult{Miner: miner}
+			result.Response, result.Error = c.SubmitTransaction(broadcastCtx, miner, tx, opts.SubmitOpts...)
+			result.Accepted = result.Error == nil && isAcceptedSubmission(result.Response)
+
+			lock.Lock()
+			defer lock.Unlock()
+			response.Results[i] = result
+			if result.Accepted {
+				response.Accepted++
+				if opts.CancelOnQuorum && response.Accepted >= quorum {
+					cancel()
+				}
+			}
+		}(i, miner)
+	}
+
+	// Waiting for all requests to finish
+	wg.Wait()
+
+	if response.QuorumReached = response.Accepted >= quorum; !response.QuorumReached {
+		return response, ErrQuorumNotReached
+	}
+	return response, nil
+}
+
+// isAcceptedSubmission will return true if the miner accepted the transaction
+func isAcceptedSubmission(response *SubmitTransactionResponse) bool {
+	if response == nil || response.Results == nil {
+		return false
+	}
+	if response.Results.AlreadyKnown {
+		return true
+	}
+
+	switch response.APIType {
+	case MAPI:
+		return response.Results.ReturnResult == QueryTransactionSuccess
+	case Arc:
+		return response.Results.TxStatus.AtLeast(arc.Queued)
+	}
+	return false
+}
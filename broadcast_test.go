@@ -0,0 +1,107 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHTTPBroadcast for mocking a broadcast where GorillaPool rejects or hangs
+type mockHTTPBroadcast struct {
+	hang bool
+}
+
+// Do is a mock http request
+func (m *mockHTTPBroadcast) Do(req *http.Request) (*http.Response, error) {
+	if !strings.Contains(req.URL.Host, "gorillapool") {
+		return (&mockHTTPValidSubmission{}).Do(req)
+	}
+	if m.hang {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+	return (&mockHTTPSequence{responses: []mockResponse{
+		{statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionFailure, false)},
+	}}).Do(req)
+}
+
+// TestClient_Broadcast tests the method Broadcast()
+func TestClient_Broadcast(t *testing.T) {
+	t.Parallel()
+
+	tx := &Transaction{RawTx: submitTestExampleTx}
+
+	t.Run("all miners accept", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		response, err := client.Broadcast(context.Background(), tx, nil)
+		require.NoError(t, err)
+		require.NotNil(t, response)
+
+		assert.True(t, response.QuorumReached)
+		assert.Equal(t, len(client.Miners()), response.Quorum)
+		assert.Equal(t, len(client.Miners()), response.Accepted)
+		for i, result := range response.Results {
+			assert.True(t, result.Accepted)
+			assert.NoError(t, result.Error)
+			assert.Equal(t, client.Miners()[i], result.Miner)
+		}
+	})
+
+	t.Run("quorum reached with a rejection", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBroadcast{})
+		response, err := client.Broadcast(context.Background(), tx, &BroadcastOptions{Quorum: 1})
+		require.NoError(t, err)
+		assert.True(t, response.QuorumReached)
+		assert.Equal(t, 1, response.Accepted)
+
+		rejected := response.Results[1]
+		assert.Equal(t, MinerGorillaPool, rejected.Miner.Name)
+		assert.False(t, rejected.Accepted)
+		require.NotNil(t, rejected.Response)
+		assert.Equal(t, QueryTransactionFailure, rejected.Response.Results.ReturnResult)
+	})
+
+	t.Run("quorum not reached", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBroadcast{})
+		response, err := client.Broadcast(context.Background(), tx, nil)
+		require.ErrorIs(t, err, ErrQuorumNotReached)
+		require.NotNil(t, response)
+		assert.False(t, response.QuorumReached)
+		assert.Equal(t, 1, response.Accepted)
+	})
+
+	t.Run("subset of miners", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBroadcast{})
+		response, err := client.Broadcast(context.Background(), tx, &BroadcastOptions{
+			Miners: []*Miner{client.MinerByName(MinerTaal)},
+		})
+		require.NoError(t, err)
+		require.Len(t, response.Results, 1)
+		assert.Equal(t, 1, response.Quorum)
+	})
+
+	t.Run("cancel on quorum", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBroadcast{hang: true})
+		response, err := client.Broadcast(context.Background(), tx, &BroadcastOptions{
+			CancelOnQuorum: true,
+			Quorum:         1,
+		})
+		require.NoError(t, err)
+		assert.True(t, response.QuorumReached)
+		assert.True(t, errors.Is(response.Results[1].Error, context.Canceled))
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		_, err := client.Broadcast(context.Background(), nil, nil)
+		require.Error(t, err)
+
+		_, err = client.Broadcast(context.Background(), tx, &BroadcastOptions{Quorum: 10})
+		require.Error(t, err)
+	})
+}
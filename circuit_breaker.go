@@ -0,0 +1,208 @@
+package minercraft
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when the circuit breaker of the miner API is open
+//
+// It's wrapped in ErrRetryable as the request can be sent to another miner.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of the circuit breaker of a miner API
+type CircuitState string
+
+const (
+	// CircuitClosed is the state when the requests are sent
+	CircuitClosed CircuitState = "closed"
+
+	// CircuitOpen is the state when the requests are rejected (the miner API is failing)
+	CircuitOpen CircuitState = "open"
+
+	// CircuitHalfOpen is the state when a few probe requests are sent to check the miner API
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// circuitBreaker is the circuit breaker of a miner API
+type circuitBreaker struct {
+	failures int
+	openedAt time.Time
+	probes   int
+	state    CircuitState
+}
+
+// circuitBreakers are the circuit breakers of the miner APIs (by API URL)
+type circuitBreakers struct {
+	sync.Mutex
+	breakers         map[string]*circuitBreaker
+	failureThreshold int
+	halfOpenProbes   int
+	openTimeout      time.Duration
+}
+
+// newCircuitBreakers will return the circuit breakers, nil if they are disabled in the options
+func newCircuitBreakers(options *ClientOptions) *circuitBreakers {
+	if options.CircuitBreakerFailureThreshold <= 0 {
+		return nil
+	}
+	probes := options.CircuitBreakerHalfOpenProbes
+	if probes <= 0 {
+		probes = 1
+	}
+	return &circuitBreakers{
+		breakers:         make(map[string]*circuitBreaker),
+		failureThreshold: options.CircuitBreakerFailureThreshold,
+		halfOpenProbes:   probes,
+		openTimeout:      options.CircuitBreakerOpenTimeout,
+	}
+}
+
+// breaker will return the circuit breaker of the endpoint with its current state (lock must be held)
+func (b *circuitBreakers) breaker(endpoint string) *circuitBreaker {
+	breaker, ok := b.breakers[endpoint]
+	if !ok {
+		breaker = &circuitBreaker{state: CircuitClosed}
+		b.breakers[endpoint] = breaker
+	}
+	if breaker.state == CircuitOpen && time.Since(breaker.openedAt) >= b.openTimeout {
+		breaker.state = CircuitHalfOpen
+		breaker.probes = 0
+	}
+	return breaker
+}
+
+// state will return the state of the circuit breaker of the endpoint
+func (b *circuitBreakers) state(endpoint string) CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.Lock()
+	defer b.Unlock()
+	return b.breaker(endpoint).state
+}
+
+// states will return the state of all the circuit breakers (by API URL)
+func (b *circuitBreakers) states() map[string]CircuitState {
+	states := make(map[string]CircuitState)
+	if b == nil {
+		return states
+	}
+	b.Lock()
+	defer b.Unlock()
+	for endpoint := range b.breakers {
+		states[endpoint] = b.breaker(endpoint).state
+	}
+	return states
+}
+
+// allow will return an error if the request to the endpoint is not allowed
+func (b *circuitBreakers) allow(endpoint string) error {
+	if b == nil || len(endpoint) == 0 {
+		return nil
+	}
+	b.Lock()
+	defer b.Unlock()
+
+	breaker := b.breaker(endpoint)
+	switch breaker.state {
+	case CircuitOpen:
+		return ErrRetryable{err: ErrCircuitOpen}
+	case CircuitHalfOpen:
+		if breaker.probes >= b.halfOpenProbes {
+			return ErrRetryable{err: ErrCircuitOpen}
+		}
+		breaker.probes++
+	}
+	return nil
+}
+
+// record will record the result of a request to the endpoint, it returns true if the circuit opened
+func (b *circuitBreakers) record(endpoint string, success bool) (opened bool) {
+	if b == nil || len(endpoint) == 0 {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+
+	breaker := b.breaker(endpoint)
+	if success {
+		breaker.state = CircuitClosed
+		breaker.failures = 0
+		breaker.probes = 0
+		return
+	}
+
+	breaker.failures++
+	if breaker.state == CircuitHalfOpen || breaker.failures >= b.failureThreshold {
+		opened = breaker.state != CircuitOpen
+		breaker.state = CircuitOpen
+		breaker.openedAt = time.Now()
+		breaker.probes = 0
+	}
+	return
+}
+
+// release will give back the half-open probe of a request that did not get a result
+func (b *circuitBreakers) release(endpoint string) {
+	if b == nil || len(endpoint) == 0 {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+
+	if breaker := b.breaker(endpoint); breaker.state == CircuitHalfOpen && breaker.probes > 0 {
+		breaker.probes--
+	}
+}
+
+// forget will remove the circuit breaker of the endpoint (the API was removed)
+func (b *circuitBreakers) forget(endpoint string) {
+	if b == nil {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	delete(b.breakers, endpoint)
+}
+
+// isCircuitFailure will return true if the request attempt shows the miner API is failing
+func isCircuitFailure(response *RequestResponse, attempt *httpAttempt) bool {
+	return attempt.transportError || (response.StatusCode >= 500 && response.StatusCode <= 599)
+}
+
+// CircuitState will return the state of the circuit breaker of the miner API
+// (see ClientOptions.CircuitBreakerFailureThreshold)
+//
+// The API is resolved the same way as the requests (see SubmitTransaction), it's always
+// closed if the circuit breakers are disabled.
+func (c *Client) CircuitState(miner *Miner, apiType APIType) CircuitState {
+	if miner == nil {
+		return CircuitClosed
+	}
+	api, err := c.minerAPI(miner, apiType)
+	if err != nil {
+		return CircuitClosed
+	}
+	return c.circuitBreakers.state(api.URL)
+}
+
+// CircuitStates will return the state of the circuit breakers of the miner APIs (by API URL)
+func (c *Client) CircuitStates() map[string]CircuitState {
+	return c.circuitBreakers.states()
+}
+
+// availableMiners will return the miners without an open circuit breaker
+func (c *Client) availableMiners(miners []*Miner, apiType APIType) []*Miner {
+	if c.circuitBreakers == nil {
+		return miners
+	}
+	available := make([]*Miner, 0, len(miners))
+	for _, miner := range miners {
+		if c.CircuitState(miner, apiType) != CircuitOpen {
+			available = append(available, miner)
+		}
+	}
+	return available
+}
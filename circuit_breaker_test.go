@@ -0,0 +1,201 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCircuitClient returns a client with the circuit breakers enabled (and no retries)
+func newTestCircuitClient(t *testing.T, httpClient HTTPInterface, openTimeout time.Duration) *Client {
+	options := DefaultClientOptions()
+	options.RequestRetryCount = 0
+	options.CircuitBreakerFailureThreshold = 2
+	options.CircuitBreakerOpenTimeout = openTimeout
+	client, err := createClient(options, MAPI, httpClient, nil, nil)
+	require.NoError(t, err)
+	return client
+}
+
+// mockHTTPCancel for mocking a request canceled by the context (with an untyped error, like heimdall)
+type mockHTTPCancel struct {
+	cancel context.CancelFunc
+}
+
+// Do is a mock http request
+func (m *mockHTTPCancel) Do(_ *http.Request) (*http.Response, error) {
+	m.cancel()
+	return nil, errors.New("request canceled")
+}
+
+// TestClient_CircuitBreaker tests the circuit breakers of the miner APIs
+func TestClient_CircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	tx := &Transaction{RawTx: submitTestExampleTx}
+	taalDown := map[string]mockResponse{"taal": {statusCode: http.StatusServiceUnavailable}}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		client := newTestClient(&mockHTTPByHost{responses: taalDown})
+		taal := client.MinerByName(MinerTaal)
+		for i := 0; i < 5; i++ {
+			_, err := client.SubmitTransaction(context.Background(), taal, tx)
+			require.Error(t, err)
+		}
+		assert.Equal(t, CircuitClosed, client.CircuitState(taal, ""))
+		assert.Empty(t, client.CircuitStates())
+	})
+
+	t.Run("opens after the failure threshold", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusServiceUnavailable}}}
+		client := newTestCircuitClient(t, httpClient, time.Minute)
+		taal := client.MinerByName(MinerTaal)
+
+		_, err := client.SubmitTransaction(context.Background(), taal, tx)
+		require.Error(t, err)
+		assert.Equal(t, CircuitClosed, client.CircuitState(taal, ""))
+
+		_, err = client.SubmitTransaction(context.Background(), taal, tx)
+		require.Error(t, err)
+		assert.Equal(t, CircuitOpen, client.CircuitState(taal, ""))
+		assert.Equal(t, CircuitOpen, client.CircuitStates()["https://merchantapi.taal.com"])
+
+		// Fails fast
+		_, err = client.SubmitTransaction(context.Background(), taal, tx)
+		require.ErrorIs(t, err, ErrCircuitOpen)
+		assert.True(t, IsRetryable(err))
+		assert.Equal(t, 2, httpClient.calls)
+
+		// Other APIs are not affected
+		assert.Equal(t, CircuitClosed, client.CircuitState(taal, Arc))
+	})
+
+	t.Run("client errors do not open the circuit", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusBadRequest, body: `{}`}}}
+		client := newTestCircuitClient(t, httpClient, time.Minute)
+		taal := client.MinerByName(MinerTaal)
+		for i := 0; i < 3; i++ {
+			_, err := client.SubmitTransaction(context.Background(), taal, tx)
+			require.Error(t, err)
+		}
+		assert.Equal(t, CircuitClosed, client.CircuitState(taal, ""))
+	})
+
+	t.Run("half open probe closes the circuit", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusServiceUnavailable},
+			{statusCode: http.StatusServiceUnavailable},
+			{statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionSuccess, false)},
+		}}
+		client := newTestCircuitClient(t, httpClient, 10*time.Millisecond)
+		taal := client.MinerByName(MinerTaal)
+		for i := 0; i < 2; i++ {
+			_, _ = client.SubmitTransaction(context.Background(), taal, tx)
+		}
+		assert.Equal(t, CircuitOpen, client.CircuitState(taal, ""))
+
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, CircuitHalfOpen, client.CircuitState(taal, ""))
+
+		_, err := client.SubmitTransaction(context.Background(), taal, tx)
+		require.NoError(t, err)
+		assert.Equal(t, CircuitClosed, client.CircuitState(taal, ""))
+	})
+
+	t.Run("canceled requests are not recorded", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusServiceUnavailable}}}
+		client := newTestCircuitClient(t, httpClient, time.Minute)
+		taal := client.MinerByName(MinerTaal)
+		_, _ = client.SubmitTransaction(context.Background(), taal, tx)
+
+		for i := 0; i < 3; i++ {
+			ctx, cancel := context.WithCancel(context.Background())
+			client.httpClient = &mockHTTPCancel{cancel: cancel}
+			_, err := client.SubmitTransaction(ctx, taal, tx)
+			require.Error(t, err)
+		}
+		assert.Equal(t, CircuitClosed, client.CircuitState(taal, ""))
+		assert.Equal(t, 1, client.circuitBreakers.breakers["https://merchantapi.taal.com"].failures)
+	})
+
+	t.Run("canceled half open probe is released", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusServiceUnavailable},
+			{statusCode: http.StatusServiceUnavailable},
+			{statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionSuccess, false)},
+		}}
+		client := newTestCircuitClient(t, httpClient, 10*time.Millisecond)
+		taal := client.MinerByName(MinerTaal)
+		for i := 0; i < 2; i++ {
+			_, _ = client.SubmitTransaction(context.Background(), taal, tx)
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		client.httpClient = &mockHTTPCancel{cancel: cancel}
+		_, err := client.SubmitTransaction(ctx, taal, tx)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, CircuitHalfOpen, client.CircuitState(taal, ""))
+
+		// The probe is available again
+		client.httpClient = httpClient
+		_, err = client.SubmitTransaction(context.Background(), taal, tx)
+		require.NoError(t, err)
+		assert.Equal(t, CircuitClosed, client.CircuitState(taal, ""))
+	})
+
+	t.Run("half open probe failure opens the circuit", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusServiceUnavailable}}}
+		client := newTestCircuitClient(t, httpClient, 10*time.Millisecond)
+		taal := client.MinerByName(MinerTaal)
+		for i := 0; i < 2; i++ {
+			_, _ = client.SubmitTransaction(context.Background(), taal, tx)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		_, err := client.SubmitTransaction(context.Background(), taal, tx)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, CircuitOpen, client.CircuitState(taal, ""))
+	})
+
+	t.Run("open circuits are skipped", func(t *testing.T) {
+		client := newTestCircuitClient(t, &mockHTTPByHost{responses: taalDown}, time.Minute)
+		taal := client.MinerByName(MinerTaal)
+		for i := 0; i < 2; i++ {
+			_, _ = client.SubmitTransaction(context.Background(), taal, tx)
+		}
+		require.Equal(t, CircuitOpen, client.CircuitState(taal, ""))
+
+		miner, err := client.selectMiner(context.Background(), NewPrioritySelector(MinerTaal), "")
+		require.NoError(t, err)
+		assert.Equal(t, MinerGorillaPool, miner.Name)
+
+		response, err := client.Broadcast(context.Background(), tx, &BroadcastOptions{Quorum: 1})
+		require.NoError(t, err)
+		assert.ErrorIs(t, response.Results[0].Error, ErrCircuitOpen)
+		assert.True(t, response.Results[1].Accepted)
+	})
+
+	t.Run("best quote skips open circuits", func(t *testing.T) {
+		client := newTestCircuitClient(t, &mockHTTPByHost{
+			fallback:  &mockHTTPValidFeeQuote{},
+			responses: taalDown,
+		}, time.Minute)
+		taal := client.MinerByName(MinerTaal)
+		for i := 0; i < 2; i++ {
+			_, _ = client.FeeQuote(context.Background(), taal)
+		}
+		require.Equal(t, CircuitOpen, client.CircuitState(taal, ""))
+
+		quote, err := client.FastestQuote(context.Background(), time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, MinerGorillaPool, quote.Miner.Name)
+	})
+}
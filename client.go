@@ -9,9 +9,9 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/gojektech/heimdall/v6"
 	"github.com/gojektech/heimdall/v6/httpclient"
 )
 
@@ -22,15 +22,24 @@ type HTTPInterface interface {
 
 // Client is the parent struct that contains the miner clients and list of miners to use
 type Client struct {
-	apiType    APIType        // The API type to use
-	httpClient HTTPInterface  // Interface for all HTTP requests
-	miners     []*Miner       // List of loaded miners
-	minerAPIs  []*MinerAPIs   // List of loaded miners APIs
-	Options    *ClientOptions // Client options config
+	apiType          APIType          // The default API type to use
+	circuitBreakers  *circuitBreakers // Circuit breakers of the miner APIs (nil if disabled)
+	customHTTPClient bool             // Set if the HTTP client was given (it handles its own retries)
+	healthMonitor    *healthMonitor   // Health of the miners (see StartHealthMonitor)
+	httpClient       HTTPInterface    // Interface for all HTTP requests
+	logger           Logger           // Logger for the retries and circuit breakers (optional)
+	miners           []*Miner         // List of loaded miners (copy-on-write)
+	minerAPIs        []*MinerAPIs     // List of loaded miners APIs (copy-on-write)
+	Options          *ClientOptions   // Client options config
+	rateLimiters     *rateLimiters    // Rate limiters of the miner API tokens
+	registryLock     sync.RWMutex     // Protects the miners and miners APIs
 }
 
 // AddMiner will add a new miner to the list of miners
 func (c *Client) AddMiner(miner Miner, apis []API) error {
+	c.registryLock.Lock()
+	defer c.registryLock.Unlock()
+
 	// Check if miner name is empty
 	if len(miner.Name) == 0 {
 		return errors.New("missing miner name")
@@ -42,38 +51,27 @@ func (c *Client) AddMiner(miner Miner, apis []API) error {
 	}
 
 	// Check if a miner with that name already exists
-	existingMiner := c.MinerByName(miner.Name)
+	existingMiner := MinerByName(c.miners, miner.Name)
 	if existingMiner != nil {
 		return fmt.Errorf("miner %s already exists", miner.Name)
 	}
 
 	// Check if a miner with the minerID already exists
 	if len(miner.MinerID) > 0 {
-		if existingMiner = c.MinerByID(miner.MinerID); existingMiner != nil {
+		if existingMiner = MinerByID(c.miners, miner.MinerID); existingMiner != nil {
 			return fmt.Errorf("miner %s already exists", miner.MinerID)
 		}
 	}
 
 	// Check if the MinerAPIs already exist for the given MinerID
-	existingMinerAPIs := c.MinerAPIsByMinerID(miner.MinerID)
+	existingMinerAPIs := c.minerAPIsByMinerID(miner.MinerID)
 	if existingMinerAPIs != nil {
 		return fmt.Errorf("miner APIs for MinerID %s already exist", miner.MinerID)
 	}
 
-	// Check if the API types are valid
-	for _, api := range apis {
-		if !isValidAPIType(api.Type) {
-			return fmt.Errorf("invalid API type: %s", api.Type)
-		}
-	}
-
-	// Check if the API types are unique within the provided APIs
-	apiTypes := make(map[APIType]bool)
-	for _, api := range apis {
-		if apiTypes[api.Type] {
-			return fmt.Errorf("duplicate API type found: %s", api.Type)
-		}
-		apiTypes[api.Type] = true
+	// Check if the API types are valid and unique
+	if err := validateAPIs(apis); err != nil {
+		return err
 	}
 
 	// Check if the MinerID is unique or generate a new one
@@ -81,24 +79,34 @@ func (c *Client) AddMiner(miner Miner, apis []API) error {
 		miner.MinerID = generateUniqueMinerID()
 	}
 
-	// Append the new miner
-	c.miners = append(c.miners, &miner)
+	// Append the new miner (copy-on-write, the previous lists can still be in use)
+	c.miners = append(c.minersCopy(), &miner)
 
 	// Append the new miner APIs
-	c.minerAPIs = append(c.minerAPIs, &MinerAPIs{
+	c.minerAPIs = append(append([]*MinerAPIs(nil), c.minerAPIs...), &MinerAPIs{
 		MinerID: miner.MinerID,
-		APIs:    apis,
+		APIs:    append([]API(nil), apis...),
 	})
 
 	return nil
 }
 
-// RemoveMiner will remove a miner from the list
+// RemoveMiner will remove a miner from the list (and its APIs)
 func (c *Client) RemoveMiner(miner *Miner) bool {
+	c.registryLock.Lock()
+	defer c.registryLock.Unlock()
+
 	for i, m := range c.miners {
 		if m.Name == miner.Name || m.MinerID == miner.MinerID {
-			c.miners[i] = c.miners[len(c.miners)-1]
-			c.miners = c.miners[:len(c.miners)-1]
+			miners := c.minersCopy()
+			miners[i] = miners[len(miners)-1]
+			c.miners = miners[:len(miners)-1]
+
+			// Remove the APIs and the health unless another miner uses the same MinerID
+			if MinerByID(c.miners, m.MinerID) == nil {
+				c.removeMinerAPIs(m.MinerID)
+				c.healthMonitor.forget(m.MinerID)
+			}
 			return true
 		}
 	}
@@ -106,9 +114,22 @@ func (c *Client) RemoveMiner(miner *Miner) bool {
 	return false
 }
 
-// MinerByName will return a miner given a name
+// minersCopy will return a copy of the list of miners (lock must be held)
+func (c *Client) minersCopy() []*Miner {
+	return append([]*Miner(nil), c.miners...)
+}
+
+// minersSnapshot will return the current list of miners, the list is never modified
+// so it can be used without holding the lock
+func (c *Client) minersSnapshot() []*Miner {
+	c.registryLock.RLock()
+	defer c.registryLock.RUnlock()
+	return c.miners
+}
+
+// MinerByName will return a copy of a miner given a name
 func (c *Client) MinerByName(name string) *Miner {
-	return MinerByName(c.miners, name)
+	return copyMiner(MinerByName(c.minersSnapshot(), name))
 }
 
 // MinerByName will return a miner from a given set of miners
@@ -121,9 +142,9 @@ func MinerByName(miners []*Miner, minerName string) *Miner {
 	return nil
 }
 
-// MinerByID will return a miner given a miner id
+// MinerByID will return a copy of a miner given a miner id
 func (c *Client) MinerByID(minerID string) *Miner {
-	return MinerByID(c.miners, minerID)
+	return copyMiner(MinerByID(c.minersSnapshot(), minerID))
 }
 
 // MinerByID will return a miner from a given set of miners
@@ -137,7 +158,16 @@ func MinerByID(miners []*Miner, minerID string) *Miner {
 }
 
 // MinerAPIByMinerID will return a miner's API given a miner id and API type
+//
+// The API must not be modified, use MinerUpdateToken instead.
 func (c *Client) MinerAPIByMinerID(minerID string, apiType APIType) (*API, error) {
+	c.registryLock.RLock()
+	defer c.registryLock.RUnlock()
+	return c.minerAPIByMinerID(minerID, apiType)
+}
+
+// minerAPIByMinerID will return a miner's API given a miner id and API type (lock must be held)
+func (c *Client) minerAPIByMinerID(minerID string, apiType APIType) (*API, error) {
 	for _, minerAPI := range c.minerAPIs {
 		if minerAPI.MinerID == minerID {
 			for i := range minerAPI.APIs {
@@ -151,7 +181,16 @@ func (c *Client) MinerAPIByMinerID(minerID string, apiType APIType) (*API, error
 }
 
 // MinerAPIsByMinerID will return a miner's APIs given a miner id
+//
+// The APIs must not be modified, use SetMinerAPIPreference or MinerUpdateToken instead.
 func (c *Client) MinerAPIsByMinerID(minerID string) *MinerAPIs {
+	c.registryLock.RLock()
+	defer c.registryLock.RUnlock()
+	return c.minerAPIsByMinerID(minerID)
+}
+
+// minerAPIsByMinerID will return a miner's APIs given a miner id (lock must be held)
+func (c *Client) minerAPIsByMinerID(minerID string) *MinerAPIs {
 	for _, minerAPIs := range c.minerAPIs {
 		if minerAPIs.MinerID == minerID {
 			return minerAPIs
@@ -160,6 +199,78 @@ func (c *Client) MinerAPIsByMinerID(minerID string) *MinerAPIs {
 	return nil
 }
 
+// SetMinerAPIPreference will set the preferred API types (in order) for a given miner id
+//
+// Every API type must be valid and configured for the miner. Passing no API types
+// will remove the preference and the client API type will be used again.
+func (c *Client) SetMinerAPIPreference(minerID string, apiTypes ...APIType) error {
+	c.registryLock.Lock()
+	defer c.registryLock.Unlock()
+
+	minerAPIs := c.minerAPIsByMinerID(minerID)
+	if minerAPIs == nil {
+		return fmt.Errorf("miner APIs for MinerID %s not found", minerID)
+	}
+
+	for _, apiType := range apiTypes {
+		if !isValidAPIType(apiType) {
+			return fmt.Errorf("invalid API type: %s", apiType)
+		}
+		if _, err := c.minerAPIByMinerID(minerID, apiType); err != nil {
+			return err
+		}
+	}
+
+	c.replaceMinerAPIs(minerAPIs, func(updated *MinerAPIs) {
+		updated.PreferredAPITypes = append([]APIType(nil), apiTypes...)
+	})
+	return nil
+}
+
+// replaceMinerAPIs will replace the miner APIs with an updated copy (lock must be held)
+//
+// The published MinerAPIs are never modified, so they can be used without holding the lock.
+func (c *Client) replaceMinerAPIs(minerAPIs *MinerAPIs, update func(updated *MinerAPIs)) {
+	updated := &MinerAPIs{
+		MinerID:           minerAPIs.MinerID,
+		APIs:              append([]API(nil), minerAPIs.APIs...),
+		PreferredAPITypes: minerAPIs.PreferredAPITypes,
+	}
+	update(updated)
+
+	list := make([]*MinerAPIs, len(c.minerAPIs))
+	for i, existing := range c.minerAPIs {
+		list[i] = existing
+		if existing == minerAPIs {
+			list[i] = updated
+		}
+	}
+	c.minerAPIs = list
+}
+
+// minerAPI will return the API to use for a given miner
+//
+// The API type is picked in this order: the requested API type (if set), the first
+// preferred API type of the miner that is configured, and then the client API type.
+func (c *Client) minerAPI(miner *Miner, apiType APIType) (*API, error) {
+	c.registryLock.RLock()
+	defer c.registryLock.RUnlock()
+
+	if len(apiType) > 0 {
+		return c.minerAPIByMinerID(miner.MinerID, apiType)
+	}
+
+	if minerAPIs := c.minerAPIsByMinerID(miner.MinerID); minerAPIs != nil {
+		for _, preferred := range minerAPIs.PreferredAPITypes {
+			if api, err := c.minerAPIByMinerID(miner.MinerID, preferred); err == nil {
+				return api, nil
+			}
+		}
+	}
+
+	return c.minerAPIByMinerID(miner.MinerID, c.apiType)
+}
+
 // ActionRouteByAPIType will return the route for a given action and API type
 func ActionRouteByAPIType(actionName APIActionName, apiType APIType) (string, error) {
 	for _, apiRoute := range Routes {
@@ -175,16 +286,40 @@ func ActionRouteByAPIType(actionName APIActionName, apiType APIType) (string, er
 }
 
 // MinerUpdateToken will find a miner by name and update the token
+//
+// Unknown miners and API types are ignored, use UpdateMinerToken to get an error instead.
 func (c *Client) MinerUpdateToken(name, token string, apiType APIType) {
-	if miner := c.MinerByName(name); miner != nil {
-		api, _ := c.MinerAPIByMinerID(miner.MinerID, apiType)
-		api.Token = token
-	}
+	_ = c.UpdateMinerToken(name, token, apiType)
 }
 
-// Miners will return the list of miners
+// replaceMinerAPI will replace the API (of the same type) of a miner (lock must be held)
+func (c *Client) replaceMinerAPI(minerID string, api API) {
+	c.replaceMinerAPIs(c.minerAPIsByMinerID(minerID), func(updated *MinerAPIs) {
+		for i := range updated.APIs {
+			if updated.APIs[i].Type == api.Type {
+				updated.APIs[i] = api
+			}
+		}
+	})
+}
+
+// Miners will return a copy of the list of miners
 func (c *Client) Miners() []*Miner {
-	return c.miners
+	miners := c.minersSnapshot()
+	list := make([]*Miner, len(miners))
+	for i, miner := range miners {
+		list[i] = copyMiner(miner)
+	}
+	return list
+}
+
+// copyMiner will return a copy of the miner (the miners of the client must not be modified)
+func copyMiner(miner *Miner) *Miner {
+	if miner == nil {
+		return nil
+	}
+	minerCopy := *miner
+	return &minerCopy
 }
 
 // UserAgent will return the user agent
@@ -198,10 +333,15 @@ type ClientOptions struct {
 	BackOffInitialTimeout          time.Duration `json:"back_off_initial_timeout"`
 	BackOffMaximumJitterInterval   time.Duration `json:"back_off_maximum_jitter_interval"`
 	BackOffMaxTimeout              time.Duration `json:"back_off_max_timeout"`
+	CircuitBreakerFailureThreshold int           `json:"circuit_breaker_failure_threshold"` // Failures in a row to open the circuit of a miner API (0 disables the circuit breakers)
+	CircuitBreakerHalfOpenProbes   int           `json:"circuit_breaker_half_open_probes"`  // Requests allowed to probe a miner API once the circuit is half-open
+	CircuitBreakerOpenTimeout      time.Duration `json:"circuit_breaker_open_timeout"`      // Time the circuit stays open before probing the miner API
 	DialerKeepAlive                time.Duration `json:"dialer_keep_alive"`
 	DialerTimeout                  time.Duration `json:"dialer_timeout"`
+	Logger                         Logger        `json:"-"` // Logs the retries and the circuit breakers (optional)
 	RequestRetryCount              int           `json:"request_retry_count"`
 	RequestTimeout                 time.Duration `json:"request_timeout"`
+	RetryPolicy                    *RetryPolicy  `json:"retry_policy,omitempty"` // Overrides RequestRetryCount and the BackOff options
 	TransportExpectContinueTimeout time.Duration `json:"transport_expect_continue_timeout"`
 	TransportIdleTimeout           time.Duration `json:"transport_idle_timeout"`
 	TransportMaxIdleConnections    int           `json:"transport_max_idle_connections"`
@@ -217,6 +357,8 @@ func DefaultClientOptions() (clientOptions *ClientOptions) {
 		BackOffInitialTimeout:          2 * time.Millisecond,
 		BackOffMaximumJitterInterval:   2 * time.Millisecond,
 		BackOffMaxTimeout:              10 * time.Millisecond,
+		CircuitBreakerHalfOpenProbes:   1,
+		CircuitBreakerOpenTimeout:      30 * time.Second,
 		DialerKeepAlive:                20 * time.Second,
 		DialerTimeout:                  5 * time.Second,
 		RequestRetryCount:              2,
@@ -231,9 +373,15 @@ func DefaultClientOptions() (clientOptions *ClientOptions) {
 
 // NewClient creates a new client for requests
 //
+// New can be used instead to set the options by name (see ClientOption).
+//
 // clientOptions: inject custom client options on load
 // customHTTPClient: use your own custom HTTP client
 // customMiners: use your own custom list of miners
+//
+// A custom HTTP client is expected to handle its own retries, so its requests are only
+// retried if a RetryPolicy is set in the options (RequestRetryCount and the BackOff options
+// only apply to the default HTTP client).
 func NewClient(clientOptions *ClientOptions, customHTTPClient HTTPInterface,
 	apiType APIType, customMiners []*Miner, customMinersAPIDef []*MinerAPIs) (client ClientInterface, err error) {
 
@@ -263,11 +411,15 @@ func createClient(options *ClientOptions, apiType APIType, customHTTPClient HTTP
 
 	// Set the options
 	c.Options = options
+	c.logger = options.Logger
+	c.circuitBreakers = newCircuitBreakers(options)
+	c.rateLimiters = newRateLimiters()
+	c.healthMonitor = newHealthMonitor()
 
 	// Load custom vs pre-defined
 	if len(customMiners) > 0 && len(customMinersAPIDef) > 0 {
-		c.miners = customMiners
-		c.minerAPIs = customMinersAPIDef
+		c.miners = append([]*Miner(nil), customMiners...)
+		c.minerAPIs = append([]*MinerAPIs(nil), customMinersAPIDef...)
 	} else {
 		c.miners, err = DefaultMiners()
 		if err != nil {
@@ -282,6 +434,7 @@ func createClient(options *ClientOptions, apiType APIType, customHTTPClient HTTP
 
 	// Is there a custom HTTP client to use?
 	if customHTTPClient != nil {
+		c.customHTTPClient = true
 		c.httpClient = customHTTPClient
 		return
 	}
@@ -299,31 +452,9 @@ func createClient(options *ClientOptions, apiType APIType, customHTTPClient HTTP
 		TLSHandshakeTimeout:   options.TransportTLSHandshakeTimeout,
 	}
 
-	// Determine the strategy for the http client
-	if options.RequestRetryCount <= 0 {
-
-		// no retry enabled
-		c.httpClient = httpclient.NewClient(
-			httpclient.WithHTTPTimeout(options.RequestTimeout),
-			httpclient.WithHTTPClient(&http.Client{
-				Transport: clientDefaultTransport,
-				Timeout:   options.RequestTimeout,
-			}),
-		)
-		return
-	}
-
-	// Retry enabled - create exponential back-off
+	// The retries are done by httpRequest (see RetryPolicy)
 	c.httpClient = httpclient.NewClient(
 		httpclient.WithHTTPTimeout(options.RequestTimeout),
-		httpclient.WithRetrier(heimdall.NewRetrier(
-			heimdall.NewExponentialBackoff(
-				options.BackOffInitialTimeout,
-				options.BackOffMaxTimeout,
-				options.BackOffExponentFactor,
-				options.BackOffMaximumJitterInterval,
-			))),
-		httpclient.WithRetryCount(options.RequestRetryCount),
 		httpclient.WithHTTPClient(&http.Client{
 			Transport: clientDefaultTransport,
 			Timeout:   options.RequestTimeout,
@@ -345,7 +476,9 @@ func DefaultMinersAPIs() (minerAPIs []*MinerAPIs, err error) {
 	return
 }
 
-// APIType will return the API type
+// APIType will return the default API type
+//
+// The API type used per miner can be changed with SetMinerAPIPreference()
 func (c *Client) APIType() APIType {
 	return c.apiType
 }
@@ -360,7 +493,7 @@ func isValidAPIType(apiType APIType) bool {
 	}
 }
 
-// isUniqueMinerID will return true if the miner ID is unique
+// isUniqueMinerID will return true if the miner ID is unique (lock must be held)
 func (c *Client) isUniqueMinerID(minerID string) bool {
 	for _, miner := range c.miners {
 		if miner.MinerID == minerID {
@@ -2,14 +2,17 @@ package minercraft
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tonicpow/go-minercraft/v2/apis/mapi"
 )
 
 const (
@@ -53,6 +56,12 @@ func newTestClient(httpClient HTTPInterface) ClientInterface {
 	return client
 }
 
+// newTestArcClient returns an Arc client for mocking (using a custom HTTP interface)
+func newTestArcClient(httpClient HTTPInterface) ClientInterface {
+	client, _ := NewClient(nil, httpClient, Arc, nil, nil)
+	return client
+}
+
 // TestNewClient tests the method NewClient()
 func TestNewClient(t *testing.T) {
 	t.Parallel()
@@ -568,6 +577,56 @@ func BenchmarkClient_MinerUpdateToken(b *testing.B) {
 	}
 }
 
+// TestClient_SetMinerAPIPreference tests the method SetMinerAPIPreference()
+func TestClient_SetMinerAPIPreference(t *testing.T) {
+	t.Parallel()
+
+	t.Run("prefer arc for a miner", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidArcFeeQuote{})
+		taal := client.MinerByName(MinerTaal)
+
+		err := client.SetMinerAPIPreference(taal.MinerID, Arc, MAPI)
+		require.NoError(t, err)
+		assert.Equal(t, []APIType{Arc, MAPI}, client.MinerAPIsByMinerID(taal.MinerID).PreferredAPITypes)
+
+		// Client default is still mAPI, but Taal now uses Arc
+		assert.Equal(t, MAPI, client.APIType())
+		response, err := client.FeeQuote(context.Background(), taal)
+		require.NoError(t, err)
+		assert.Equal(t, Arc, response.APIType)
+	})
+
+	t.Run("reset the preference", func(t *testing.T) {
+		client := newTestClient(&mockHTTPDefaultClient{})
+		taal := client.MinerByName(MinerTaal)
+
+		require.NoError(t, client.SetMinerAPIPreference(taal.MinerID, Arc))
+		require.NoError(t, client.SetMinerAPIPreference(taal.MinerID))
+		assert.Empty(t, client.MinerAPIsByMinerID(taal.MinerID).PreferredAPITypes)
+	})
+
+	t.Run("unknown miner", func(t *testing.T) {
+		client := newTestClient(&mockHTTPDefaultClient{})
+		err := client.SetMinerAPIPreference("unknown", Arc)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid api type", func(t *testing.T) {
+		client := newTestClient(&mockHTTPDefaultClient{})
+		err := client.SetMinerAPIPreference(client.MinerByName(MinerTaal).MinerID, "invalid")
+		require.Error(t, err)
+	})
+
+	t.Run("api type not configured", func(t *testing.T) {
+		client := newTestClient(&mockHTTPDefaultClient{})
+		err := client.AddMiner(Miner{Name: testMinerName, MinerID: testMinerID}, []API{{URL: testMinerURL, Type: MAPI}})
+		require.NoError(t, err)
+
+		err = client.SetMinerAPIPreference(testMinerID, Arc)
+		require.Error(t, err)
+	})
+}
+
 // TestClient_RemoveMiner will remove a miner by name or ID
 func TestClient_RemoveMiner(t *testing.T) {
 	t.Parallel()
@@ -661,3 +720,64 @@ func BenchmarkDefaultMiners(b *testing.B) {
 		_, _ = DefaultMiners()
 	}
 }
+
+// TestClient_Miners tests the method Miners()
+func TestClient_Miners(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns a copy", func(t *testing.T) {
+		client := newTestClient(&mockHTTPDefaultClient{})
+		miners := client.Miners()
+		require.Len(t, miners, 2)
+
+		miners[0].Name = "changed"
+		miners[1] = nil
+		_ = append(miners[:1], &Miner{Name: testMinerName})
+
+		assert.NotNil(t, client.MinerByName(MinerTaal))
+		assert.Nil(t, client.MinerByName("changed"))
+		assert.Nil(t, client.MinerByName(testMinerName))
+		assert.Len(t, client.Miners(), 2)
+	})
+
+	t.Run("miner by name and id return copies", func(t *testing.T) {
+		client := newTestClient(&mockHTTPDefaultClient{})
+		taal := client.MinerByName(MinerTaal)
+		taal.Name = "changed"
+		client.MinerByID(taal.MinerID).MinerID = "changed"
+
+		assert.NotNil(t, client.MinerByName(MinerTaal))
+		assert.Nil(t, client.MinerByName("changed"))
+		assert.Nil(t, client.MinerByID("changed"))
+		assert.Nil(t, client.MinerByName("unknown"))
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(3)
+			go func(i int) {
+				defer wg.Done()
+				name := fmt.Sprintf("miner-%d", i)
+				assert.NoError(t, client.AddMiner(Miner{Name: name}, []API{{URL: testMinerURL, Type: testAPIType}}))
+				client.MinerUpdateToken(name, "token", testAPIType)
+				assert.True(t, client.RemoveMiner(client.MinerByName(name)))
+			}(i)
+			go func() {
+				defer wg.Done()
+				_, _ = client.BestQuote(context.Background(), mapi.FeeCategoryMining, mapi.FeeTypeData)
+			}()
+			go func() {
+				defer wg.Done()
+				for _, miner := range client.Miners() {
+					_, _ = client.MinerAPIByMinerID(miner.MinerID, testAPIType)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, client.Miners(), 2)
+	})
+}
@@ -32,6 +32,8 @@ const (
 	SubmitTx APIActionName = "SubmitTx"
 	// SubmitTxs is the name of the Submit multiple Transactions API action
 	SubmitTxs APIActionName = "SubmitTxs"
+	// Health is the name of the Health API action
+	Health APIActionName = "Health"
 )
 
 // mAPI routes
@@ -50,18 +52,25 @@ const (
 
 	// mAPIRouteSubmitTxs is the route for submit batched transactions
 	mAPIRouteSubmitTxs = "/mapi/txs"
+
+	// mAPIRouteHealth is the route for checking the health (mAPI has no health endpoint, the fee quote is used)
+	mAPIRouteHealth = mAPIRouteFeeQuote
 )
 
 // Arc routes
 const (
 	// arcRoutePolicyQuote is the route for getting a policy quote
 	arcRoutePolicyQuote = "/v1/policy"
+	// arcRouteFeeQuote is the route for getting a fee quote (fees are part of the policy)
+	arcRouteFeeQuote = "/v1/policy"
 	// arcRouteQueryTx is the route for querying a transaction
 	arcRouteQueryTx = "/v1/tx/"
 	// arcRouteSubmitTx is the route for submit a transaction
 	arcRouteSubmitTx = "/v1/tx"
 	// arcRouteSubmitTxs is the route for submit batched transactions
 	arcRouteSubmitTxs = "/v1/txs"
+	// arcRouteHealth is the route for checking the health
+	arcRouteHealth = "/v1/health"
 )
 
 // Routes is a list of known actions with it's routes for the different APIs
@@ -77,6 +86,7 @@ var Routes = []APIRoute{
 		Name: FeeQuote,
 		Routes: []APISpecificRoute{
 			{Route: mAPIRouteFeeQuote, APIType: MAPI},
+			{Route: arcRouteFeeQuote, APIType: Arc},
 		},
 	},
 	{
@@ -100,6 +110,13 @@ var Routes = []APIRoute{
 			{Route: arcRouteSubmitTxs, APIType: Arc},
 		},
 	},
+	{
+		Name: Health,
+		Routes: []APISpecificRoute{
+			{Route: mAPIRouteHealth, APIType: MAPI},
+			{Route: arcRouteHealth, APIType: Arc},
+		},
+	},
 }
 
 const (
@@ -0,0 +1,452 @@
+package minercraft
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFormat is the format of a config file
+type ConfigFormat string
+
+const (
+	// ConfigFormatJSON is the JSON config format
+	ConfigFormatJSON ConfigFormat = "json"
+	// ConfigFormatYAML is the YAML config format
+	ConfigFormatYAML ConfigFormat = "yaml"
+)
+
+// Config is the configuration of a client (options, miners, their APIs and tokens)
+//
+// Example (YAML):
+//
+//	api_type: Arc
+//	options:
+//	  request_timeout: 10s
+//	  user_agent: my-app
+//	miners:
+//	  - name: Taal
+//	    miner_id: 03e92d3e5c3f7bd945dfbf48e7a99393b1bfb3f11f380ae30d286e7ff2aec5a270
+//	    apis:
+//	      - type: Arc
+//	        url: https://tapi.taal.com/arc
+//	        token_env: TAAL_TOKEN
+//
+// If no miners are configured, the known miners are used (see KnownMiners).
+type Config struct {
+	APIType APIType        `json:"api_type" yaml:"api_type"`
+	Miners  []*MinerConfig `json:"miners" yaml:"miners"`
+	Options OptionsConfig  `json:"options" yaml:"options"`
+}
+
+// MinerConfig is the configuration of a miner and its APIs
+type MinerConfig struct {
+	APIs    []*APIConfig `json:"apis" yaml:"apis"`
+	MinerID string       `json:"miner_id" yaml:"miner_id"`
+	Name    string       `json:"name" yaml:"name"`
+}
+
+// APIConfig is the configuration of a miner API
+//
+// The token is either set in plain text (token) or read from an environment variable (token_env)
+type APIConfig struct {
+	RateBurst int     `json:"rate_burst" yaml:"rate_burst"`
+	RateLimit float64 `json:"rate_limit" yaml:"rate_limit"`
+	Token     string  `json:"token" yaml:"token"`
+	TokenEnv  string  `json:"token_env" yaml:"token_env"`
+	Type      APIType `json:"type" yaml:"type"`
+	URL       string  `json:"url" yaml:"url"`
+}
+
+// OptionsConfig is the configuration of the ClientOptions (durations are strings, ex: "30s")
+//
+// Options that are not set keep their default value (see DefaultClientOptions)
+type OptionsConfig struct {
+	BackOffExponentFactor          float64            `json:"back_off_exponent_factor" yaml:"back_off_exponent_factor"`
+	BackOffInitialTimeout          Duration           `json:"back_off_initial_timeout" yaml:"back_off_initial_timeout"`
+	BackOffMaximumJitterInterval   Duration           `json:"back_off_maximum_jitter_interval" yaml:"back_off_maximum_jitter_interval"`
+	BackOffMaxTimeout              Duration           `json:"back_off_max_timeout" yaml:"back_off_max_timeout"`
+	CircuitBreakerFailureThreshold int                `json:"circuit_breaker_failure_threshold" yaml:"circuit_breaker_failure_threshold"`
+	CircuitBreakerHalfOpenProbes   int                `json:"circuit_breaker_half_open_probes" yaml:"circuit_breaker_half_open_probes"`
+	CircuitBreakerOpenTimeout      Duration           `json:"circuit_breaker_open_timeout" yaml:"circuit_breaker_open_timeout"`
+	DialerKeepAlive                Duration           `json:"dialer_keep_alive" yaml:"dialer_keep_alive"`
+	DialerTimeout                  Duration           `json:"dialer_timeout" yaml:"dialer_timeout"`
+	RequestRetryCount              int                `json:"request_retry_count" yaml:"request_retry_count"`
+	RequestTimeout                 Duration           `json:"request_timeout" yaml:"request_timeout"`
+	RetryPolicy                    *RetryPolicyConfig `json:"retry_policy" yaml:"retry_policy"`
+	TransportExpectContinueTimeout Duration           `json:"transport_expect_continue_timeout" yaml:"transport_expect_continue_timeout"`
+	TransportIdleTimeout           Duration           `json:"transport_idle_timeout" yaml:"transport_idle_timeout"`
+	TransportMaxIdleConnections    int                `json:"transport_max_idle_connections" yaml:"transport_max_idle_connections"`
+	TransportTLSHandshakeTimeout   Duration           `json:"transport_tls_handshake_timeout" yaml:"transport_tls_handshake_timeout"`
+	UserAgent                      string             `json:"user_agent" yaml:"user_agent"`
+}
+
+// RetryPolicyConfig is the configuration of the RetryPolicy (durations are strings, ex: "30s")
+//
+// Fields that are not set keep the value of the default retry policy
+type RetryPolicyConfig struct {
+	BackOffExponentFactor        float64  `json:"back_off_exponent_factor" yaml:"back_off_exponent_factor"`
+	BackOffInitialTimeout        Duration `json:"back_off_initial_timeout" yaml:"back_off_initial_timeout"`
+	BackOffMaximumJitterInterval Duration `json:"back_off_maximum_jitter_interval" yaml:"back_off_maximum_jitter_interval"`
+	BackOffMaxTimeout            Duration `json:"back_off_max_timeout" yaml:"back_off_max_timeout"`
+	MaxRetries                   int      `json:"max_retries" yaml:"max_retries"`
+	MaxRetryAfter                Duration `json:"max_retry_after" yaml:"max_retry_after"`
+}
+
+// UnmarshalJSON will parse the retry policy, the fields that are not set keep their default value
+func (p *RetryPolicyConfig) UnmarshalJSON(data []byte) error {
+	type retryPolicyConfig RetryPolicyConfig
+	config := retryPolicyConfig(newRetryPolicyConfig(DefaultClientOptions().retryPolicy()))
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return err
+	}
+	*p = RetryPolicyConfig(config)
+	return nil
+}
+
+// UnmarshalYAML will parse the retry policy, the fields that are not set keep their default value
+func (p *RetryPolicyConfig) UnmarshalYAML(node *yaml.Node) error {
+	type retryPolicyConfig RetryPolicyConfig
+	config := retryPolicyConfig(newRetryPolicyConfig(DefaultClientOptions().retryPolicy()))
+
+	// node.Decode does not reject the unknown keys, decode the node again with a strict decoder
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(&config); err != nil {
+		return err
+	}
+	*p = RetryPolicyConfig(config)
+	return nil
+}
+
+// Duration is a time.Duration that is set from a string in the config files (ex: "1m30s")
+type Duration time.Duration
+
+// UnmarshalText will parse the duration from a string (ex: "1m30s")
+func (d *Duration) UnmarshalText(text []byte) error {
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// MarshalText will return the duration as a string (ex: "1m30s")
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// LoadConfig will load the config from a JSON or YAML file (using the file extension)
+// and validate it
+func LoadConfig(path string) (*Config, error) {
+	var format ConfigFormat
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = ConfigFormatJSON
+	case ".yaml", ".yml":
+		format = ConfigFormatYAML
+	default:
+		return nil, fmt.Errorf("unsupported config file extension: %s", path)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // the path is given by the caller
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data, format)
+}
+
+// ParseConfig will parse the config from JSON or YAML data and validate it (unknown keys are an error)
+func ParseConfig(data []byte, format ConfigFormat) (*Config, error) {
+
+	// Start from the default options, the unset options keep their default value
+	config := &Config{Options: newOptionsConfig(DefaultClientOptions())}
+
+	// Unknown keys are rejected (ex: a misspelled token_env would leave the API without a token)
+	var err error
+	switch format {
+	case ConfigFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(config)
+	case ConfigFormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err = decoder.Decode(config); errors.Is(err, io.EOF) {
+			err = nil // Empty file
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing %s config: %w", format, err)
+	}
+
+	if err = config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// NewClientFromConfig will load the config file (see LoadConfig) and create a new client with it
+//
+// The given options are applied after the config (ex: WithHTTPClient, WithLogger)
+func NewClientFromConfig(path string, opts ...ClientOption) (ClientInterface, error) {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return config.NewClient(opts...)
+}
+
+// NewClient will create a new client using the config
+//
+// The given options are applied after the config (ex: WithHTTPClient, WithLogger)
+func (c *Config) NewClient(opts ...ClientOption) (ClientInterface, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	miners, minerAPIs, err := c.MinersAndAPIs()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(append([]ClientOption{
+		WithClientOptions(c.ClientOptions()),
+		WithAPIType(c.APIType),
+		WithMiners(miners, minerAPIs),
+	}, opts...)...)
+}
+
+// Validate will check the config for missing or invalid values
+func (c *Config) Validate() error {
+	if len(c.APIType) > 0 && !isValidAPIType(c.APIType) {
+		return fmt.Errorf("invalid API type: %s", c.APIType)
+	}
+	if err := c.Options.validate(); err != nil {
+		return err
+	}
+
+	names := make(map[string]bool)
+	minerIDs := make(map[string]bool)
+	for i, miner := range c.Miners {
+		if miner == nil {
+			return fmt.Errorf("miner %d is empty", i)
+		}
+		if len(miner.Name) == 0 {
+			return fmt.Errorf("miner %d is missing a name", i)
+		}
+		if len(miner.MinerID) == 0 {
+			return fmt.Errorf("miner %s is missing a miner_id", miner.Name)
+		}
+		if names[miner.Name] {
+			return fmt.Errorf("duplicate miner name found: %s", miner.Name)
+		}
+		if minerIDs[miner.MinerID] {
+			return fmt.Errorf("duplicate miner_id found: %s", miner.MinerID)
+		}
+		names[miner.Name] = true
+		minerIDs[miner.MinerID] = true
+
+		if err := miner.validate(); err != nil {
+			return fmt.Errorf("miner %s: %w", miner.Name, err)
+		}
+	}
+	return nil
+}
+
+// configValue is a named numeric value of the config (used for the validation)
+type configValue struct {
+	name  string
+	value float64
+}
+
+// validate will check that the durations and thresholds of the options are not negative
+func (o *OptionsConfig) validate() error {
+	values := []configValue{
+		{"back_off_exponent_factor", o.BackOffExponentFactor},
+		{"back_off_initial_timeout", float64(o.BackOffInitialTimeout)},
+		{"back_off_maximum_jitter_interval", float64(o.BackOffMaximumJitterInterval)},
+		{"back_off_max_timeout", float64(o.BackOffMaxTimeout)},
+		{"circuit_breaker_failure_threshold", float64(o.CircuitBreakerFailureThreshold)},
+		{"circuit_breaker_half_open_probes", float64(o.CircuitBreakerHalfOpenProbes)},
+		{"circuit_breaker_open_timeout", float64(o.CircuitBreakerOpenTimeout)},
+		{"dialer_keep_alive", float64(o.DialerKeepAlive)},
+		{"dialer_timeout", float64(o.DialerTimeout)},
+		{"request_retry_count", float64(o.RequestRetryCount)},
+		{"request_timeout", float64(o.RequestTimeout)},
+		{"transport_expect_continue_timeout", float64(o.TransportExpectContinueTimeout)},
+		{"transport_idle_timeout", float64(o.TransportIdleTimeout)},
+		{"transport_max_idle_connections", float64(o.TransportMaxIdleConnections)},
+		{"transport_tls_handshake_timeout", float64(o.TransportTLSHandshakeTimeout)},
+	}
+	if p := o.RetryPolicy; p != nil {
+		values = append(values,
+			configValue{"retry_policy.back_off_exponent_factor", p.BackOffExponentFactor},
+			configValue{"retry_policy.back_off_initial_timeout", float64(p.BackOffInitialTimeout)},
+			configValue{"retry_policy.back_off_maximum_jitter_interval", float64(p.BackOffMaximumJitterInterval)},
+			configValue{"retry_policy.back_off_max_timeout", float64(p.BackOffMaxTimeout)},
+			configValue{"retry_policy.max_retries", float64(p.MaxRetries)},
+			configValue{"retry_policy.max_retry_after", float64(p.MaxRetryAfter)},
+		)
+	}
+
+	for _, v := range values {
+		if v.value < 0 {
+			return fmt.Errorf("invalid %s: must not be negative", v.name)
+		}
+	}
+	return nil
+}
+
+// validate will check the APIs of the miner
+func (m *MinerConfig) validate() error {
+	apis := make([]API, 0, len(m.APIs))
+	for _, api := range m.APIs {
+		if api == nil {
+			return errors.New("API is empty")
+		}
+		if err := api.validate(); err != nil {
+			return err
+		}
+		apis = append(apis, API{Type: api.Type})
+	}
+	return validateAPIs(apis)
+}
+
+// validate will check the URL and the token of the API
+func (a *APIConfig) validate() error {
+	u, err := url.Parse(a.URL)
+	if err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return fmt.Errorf("invalid URL for %s API: %q", a.Type, a.URL)
+	}
+	if len(a.Token) > 0 && len(a.TokenEnv) > 0 {
+		return fmt.Errorf("both token and token_env are set for %s API", a.Type)
+	}
+	if a.RateLimit < 0 || a.RateBurst < 0 {
+		return fmt.Errorf("invalid rate limit for %s API", a.Type)
+	}
+	return nil
+}
+
+// token will return the token of the API (from the environment if token_env is set)
+func (a *APIConfig) token() (string, error) {
+	if len(a.TokenEnv) == 0 {
+		return a.Token, nil
+	}
+	token, ok := os.LookupEnv(a.TokenEnv)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set for %s API token", a.TokenEnv, a.Type)
+	}
+	return token, nil
+}
+
+// MinersAndAPIs will return the miners and their APIs of the config (tokens are read from the environment)
+func (c *Config) MinersAndAPIs() ([]*Miner, []*MinerAPIs, error) {
+	miners := make([]*Miner, 0, len(c.Miners))
+	minerAPIs := make([]*MinerAPIs, 0, len(c.Miners))
+	for _, miner := range c.Miners {
+		apis := make([]API, 0, len(miner.APIs))
+		for _, api := range miner.APIs {
+			token, err := api.token()
+			if err != nil {
+				return nil, nil, fmt.Errorf("miner %s: %w", miner.Name, err)
+			}
+			apis = append(apis, API{
+				RateBurst: api.RateBurst,
+				RateLimit: api.RateLimit,
+				Token:     token,
+				Type:      api.Type,
+				URL:       strings.TrimSuffix(api.URL, "/"),
+			})
+		}
+		miners = append(miners, &Miner{MinerID: miner.MinerID, Name: miner.Name})
+		minerAPIs = append(minerAPIs, &MinerAPIs{MinerID: miner.MinerID, APIs: apis})
+	}
+	return miners, minerAPIs, nil
+}
+
+// ClientOptions will return the client options of the config
+func (c *Config) ClientOptions() *ClientOptions {
+	o := c.Options
+	options := &ClientOptions{
+		BackOffExponentFactor:          o.BackOffExponentFactor,
+		BackOffInitialTimeout:          time.Duration(o.BackOffInitialTimeout),
+		BackOffMaximumJitterInterval:   time.Duration(o.BackOffMaximumJitterInterval),
+		BackOffMaxTimeout:              time.Duration(o.BackOffMaxTimeout),
+		CircuitBreakerFailureThreshold: o.CircuitBreakerFailureThreshold,
+		CircuitBreakerHalfOpenProbes:   o.CircuitBreakerHalfOpenProbes,
+		CircuitBreakerOpenTimeout:      time.Duration(o.CircuitBreakerOpenTimeout),
+		DialerKeepAlive:                time.Duration(o.DialerKeepAlive),
+		DialerTimeout:                  time.Duration(o.DialerTimeout),
+		RequestRetryCount:              o.RequestRetryCount,
+		RequestTimeout:                 time.Duration(o.RequestTimeout),
+		TransportExpectContinueTimeout: time.Duration(o.TransportExpectContinueTimeout),
+		TransportIdleTimeout:           time.Duration(o.TransportIdleTimeout),
+		TransportMaxIdleConnections:    o.TransportMaxIdleConnections,
+		TransportTLSHandshakeTimeout:   time.Duration(o.TransportTLSHandshakeTimeout),
+		UserAgent:                      o.UserAgent,
+	}
+	if p := o.RetryPolicy; p != nil {
+		options.RetryPolicy = &RetryPolicy{
+			BackOffExponentFactor:        p.BackOffExponentFactor,
+			BackOffInitialTimeout:        time.Duration(p.BackOffInitialTimeout),
+			BackOffMaximumJitterInterval: time.Duration(p.BackOffMaximumJitterInterval),
+			BackOffMaxTimeout:            time.Duration(p.BackOffMaxTimeout),
+			MaxRetries:                   p.MaxRetries,
+			MaxRetryAfter:                time.Duration(p.MaxRetryAfter),
+		}
+	}
+	return options
+}
+
+// newOptionsConfig will return the options config of the client options
+func newOptionsConfig(o *ClientOptions) OptionsConfig {
+	return OptionsConfig{
+		BackOffExponentFactor:          o.BackOffExponentFactor,
+		BackOffInitialTimeout:          Duration(o.BackOffInitialTimeout),
+		BackOffMaximumJitterInterval:   Duration(o.BackOffMaximumJitterInterval),
+		BackOffMaxTimeout:              Duration(o.BackOffMaxTimeout),
+		CircuitBreakerFailureThreshold: o.CircuitBreakerFailureThreshold,
+		CircuitBreakerHalfOpenProbes:   o.CircuitBreakerHalfOpenProbes,
+		CircuitBreakerOpenTimeout:      Duration(o.CircuitBreakerOpenTimeout),
+		DialerKeepAlive:                Duration(o.DialerKeepAlive),
+		DialerTimeout:                  Duration(o.DialerTimeout),
+		RequestRetryCount:              o.RequestRetryCount,
+		RequestTimeout:                 Duration(o.RequestTimeout),
+		TransportExpectContinueTimeout: Duration(o.TransportExpectContinueTimeout),
+		TransportIdleTimeout:           Duration(o.TransportIdleTimeout),
+		TransportMaxIdleConnections:    o.TransportMaxIdleConnections,
+		TransportTLSHandshakeTimeout:   Duration(o.TransportTLSHandshakeTimeout),
+		UserAgent:                      o.UserAgent,
+	}
+}
+
+// newRetryPolicyConfig will return the config of the retry policy
+func newRetryPolicyConfig(p *RetryPolicy) RetryPolicyConfig {
+	return RetryPolicyConfig{
+		BackOffExponentFactor:        p.BackOffExponentFactor,
+		BackOffInitialTimeout:        Duration(p.BackOffInitialTimeout),
+		BackOffMaximumJitterInterval: Duration(p.BackOffMaximumJitterInterval),
+		BackOffMaxTimeout:            Duration(p.BackOffMaxTimeout),
+		MaxRetries:                   p.MaxRetries,
+		MaxRetryAfter:                Duration(p.MaxRetryAfter),
+	}
+}
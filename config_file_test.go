@@ -0,0 +1,291 @@
+package minercraft
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testConfigJSON = `{
+  "api_type": "Arc",
+  "options": {
+    "request_timeout": "10s",
+    "circuit_breaker_failure_threshold": 3,
+    "retry_policy": {"max_retries": 4, "back_off_initial_timeout": "100ms", "max_retry_after": "1m"}
+  },
+  "miners": [
+    {
+      "name": "TestMiner",
+      "miner_id": "1234567",
+      "apis": [
+        {"type": "Arc", "url": "https://testminer.com/arc/", "token_env": "MINERCRAFT_TEST_TOKEN", "rate_limit": 5},
+        {"type": "mAPI", "url": "https://testminer.com", "token": "0987654321"}
+      ]
+    }
+  ]
+}`
+
+	testConfigYAML = `
+api_type: Arc
+options:
+  request_timeout: 10s
+  circuit_breaker_failure_threshold: 3
+  retry_policy:
+    max_retries: 4
+    back_off_initial_timeout: 100ms
+    max_retry_after: 1m
+miners:
+  - name: TestMiner
+    miner_id: "1234567"
+    apis:
+      - type: Arc
+        url: https://testminer.com/arc/
+        token_env: MINERCRAFT_TEST_TOKEN
+        rate_limit: 5
+      - type: mAPI
+        url: https://testminer.com
+        token: "0987654321"
+`
+)
+
+// writeTestConfig writes the config data to a file in a temporary directory
+func writeTestConfig(t *testing.T, name, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+// TestParseConfig tests the method ParseConfig()
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	for format, data := range map[ConfigFormat]string{
+		ConfigFormatJSON: testConfigJSON,
+		ConfigFormatYAML: testConfigYAML,
+	} {
+		t.Run("valid "+string(format)+" config", func(t *testing.T) {
+			config, err := ParseConfig([]byte(data), format)
+			require.NoError(t, err)
+
+			assert.Equal(t, Arc, config.APIType)
+			require.Len(t, config.Miners, 1)
+			assert.Equal(t, testMinerName, config.Miners[0].Name)
+			assert.Equal(t, testMinerID, config.Miners[0].MinerID)
+			require.Len(t, config.Miners[0].APIs, 2)
+			assert.Equal(t, "MINERCRAFT_TEST_TOKEN", config.Miners[0].APIs[0].TokenEnv)
+			assert.InDelta(t, 5.0, config.Miners[0].APIs[0].RateLimit, 0)
+
+			options := config.ClientOptions()
+			assert.Equal(t, 10*time.Second, options.RequestTimeout)
+			assert.Equal(t, 3, options.CircuitBreakerFailureThreshold)
+
+			// Not set, the default is kept
+			assert.Equal(t, DefaultClientOptions().DialerTimeout, options.DialerTimeout)
+			assert.Equal(t, defaultUserAgent, options.UserAgent)
+
+			require.NotNil(t, options.RetryPolicy)
+			assert.Equal(t, 4, options.RetryPolicy.MaxRetries)
+			assert.Equal(t, 100*time.Millisecond, options.RetryPolicy.BackOffInitialTimeout)
+			assert.Equal(t, time.Minute, options.RetryPolicy.MaxRetryAfter)
+		})
+	}
+
+	t.Run("empty config uses the defaults", func(t *testing.T) {
+		config, err := ParseConfig([]byte(`{}`), ConfigFormatJSON)
+		require.NoError(t, err)
+		assert.Empty(t, config.Miners)
+		assert.Equal(t, DefaultClientOptions(), config.ClientOptions())
+	})
+
+	t.Run("partial retry policy keeps the defaults", func(t *testing.T) {
+		defaults := DefaultClientOptions().retryPolicy()
+		for format, data := range map[ConfigFormat]string{
+			ConfigFormatJSON: `{"options": {"retry_policy": {"max_retries": 3}}}`,
+			ConfigFormatYAML: "options:\n  retry_policy:\n    max_retries: 3\n",
+		} {
+			config, err := ParseConfig([]byte(data), format)
+			require.NoError(t, err)
+
+			policy := config.ClientOptions().RetryPolicy
+			require.NotNil(t, policy, format)
+			assert.Equal(t, 3, policy.MaxRetries, format)
+			assert.Equal(t, defaultMaxRetryAfter, policy.MaxRetryAfter, format)
+			assert.Equal(t, defaults.BackOffInitialTimeout, policy.BackOffInitialTimeout, format)
+			assert.Equal(t, defaults.BackOffMaxTimeout, policy.BackOffMaxTimeout, format)
+			assert.InDelta(t, defaults.BackOffExponentFactor, policy.BackOffExponentFactor, 0, format)
+		}
+	})
+
+	t.Run("invalid configs", func(t *testing.T) {
+		tests := map[string]string{
+			"invalid json":             `{`,
+			"invalid duration":         `{"options": {"request_timeout": "ten seconds"}}`,
+			"negative duration":        `{"options": {"request_timeout": "-1s"}}`,
+			"negative threshold":       `{"options": {"circuit_breaker_failure_threshold": -1}}`,
+			"negative retry count":     `{"options": {"request_retry_count": -1}}`,
+			"negative retry policy":    `{"options": {"retry_policy": {"max_retry_after": "-1m"}}}`,
+			"negative max retries":     `{"options": {"retry_policy": {"max_retries": -1}}}`,
+			"unknown key":              `{"miners": [{"name": "a", "miner_id": "1", "apis": [{"type": "Arc", "url": "https://a.com", "token_evn": "T"}]}]}`,
+			"unknown option":           `{"options": {"request_timeot": "1s"}}`,
+			"unknown retry policy key": `{"options": {"retry_policy": {"max_retry": 3}}}`,
+			"invalid api type":         `{"api_type": "unknown"}`,
+			"missing name":             `{"miners": [{"miner_id": "1", "apis": [{"type": "Arc", "url": "https://a.com"}]}]}`,
+			"missing miner id":         `{"miners": [{"name": "a", "apis": [{"type": "Arc", "url": "https://a.com"}]}]}`,
+			"missing apis":             `{"miners": [{"name": "a", "miner_id": "1"}]}`,
+			"invalid url":              `{"miners": [{"name": "a", "miner_id": "1", "apis": [{"type": "Arc", "url": "a.com"}]}]}`,
+			"invalid api type (api)":   `{"miners": [{"name": "a", "miner_id": "1", "apis": [{"type": "b", "url": "https://a.com"}]}]}`,
+			"duplicate api type": `{"miners": [{"name": "a", "miner_id": "1", "apis": [
+				{"type": "Arc", "url": "https://a.com"}, {"type": "Arc", "url": "https://b.com"}]}]}`,
+			"token and token env": `{"miners": [{"name": "a", "miner_id": "1", "apis": [
+				{"type": "Arc", "url": "https://a.com", "token": "t", "token_env": "T"}]}]}`,
+			"duplicate name": `{"miners": [
+				{"name": "a", "miner_id": "1", "apis": [{"type": "Arc", "url": "https://a.com"}]},
+				{"name": "a", "miner_id": "2", "apis": [{"type": "Arc", "url": "https://a.com"}]}]}`,
+			"duplicate miner id": `{"miners": [
+				{"name": "a", "miner_id": "1", "apis": [{"type": "Arc", "url": "https://a.com"}]},
+				{"name": "b", "miner_id": "1", "apis": [{"type": "Arc", "url": "https://a.com"}]}]}`,
+		}
+		for name, data := range tests {
+			_, err := ParseConfig([]byte(data), ConfigFormatJSON)
+			assert.Error(t, err, name)
+		}
+	})
+
+	t.Run("unknown yaml keys", func(t *testing.T) {
+		for name, data := range map[string]string{
+			"unknown key":              "miners:\n  - name: a\n    miner_id: \"1\"\n    apis:\n      - type: Arc\n        url: https://a.com\n        token_evn: T\n",
+			"unknown retry policy key": "options:\n  retry_policy:\n    max_retry: 3\n",
+		} {
+			_, err := ParseConfig([]byte(data), ConfigFormatYAML)
+			assert.Error(t, err, name)
+		}
+
+		_, err := ParseConfig([]byte(""), ConfigFormatYAML)
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := ParseConfig([]byte(`{}`), "toml")
+		require.Error(t, err)
+	})
+}
+
+// TestLoadConfig tests the method LoadConfig()
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"config.json", "config.yaml", "config.yml"} {
+		t.Run("valid "+name, func(t *testing.T) {
+			data := testConfigJSON
+			if filepath.Ext(name) != ".json" {
+				data = testConfigYAML
+			}
+			config, err := LoadConfig(writeTestConfig(t, name, data))
+			require.NoError(t, err)
+			require.Len(t, config.Miners, 1)
+		})
+	}
+
+	t.Run("unsupported extension", func(t *testing.T) {
+		_, err := LoadConfig(writeTestConfig(t, "config.toml", testConfigJSON))
+		require.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+		require.Error(t, err)
+	})
+}
+
+// TestNewClientFromConfig tests the method NewClientFromConfig()
+func TestNewClientFromConfig(t *testing.T) {
+
+	t.Run("valid config", func(t *testing.T) {
+		t.Setenv("MINERCRAFT_TEST_TOKEN", testMinerToken)
+
+		client, err := NewClientFromConfig(
+			writeTestConfig(t, "config.yaml", testConfigYAML),
+			WithUserAgent("test-agent"),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, Arc, client.APIType())
+		assert.Equal(t, "test-agent", client.UserAgent())
+		assert.Equal(t, 10*time.Second, client.(*Client).Options.RequestTimeout)
+
+		miner := client.MinerByName(testMinerName)
+		require.NotNil(t, miner)
+
+		api, err := client.MinerAPIByMinerID(testMinerID, Arc)
+		require.NoError(t, err)
+		assert.Equal(t, testMinerToken, api.Token)
+		assert.Equal(t, testMinerURL+"/arc", api.URL)
+		assert.InDelta(t, 5.0, api.RateLimit, 0)
+
+		api, err = client.MinerAPIByMinerID(testMinerID, MAPI)
+		require.NoError(t, err)
+		assert.Equal(t, testMinerToken, api.Token)
+	})
+
+	t.Run("missing token environment variable", func(t *testing.T) {
+		t.Setenv("MINERCRAFT_TEST_TOKEN", "")
+		require.NoError(t, os.Unsetenv("MINERCRAFT_TEST_TOKEN"))
+
+		client, err := NewClientFromConfig(writeTestConfig(t, "config.json", testConfigJSON))
+		require.Error(t, err)
+		assert.Nil(t, client)
+	})
+
+	t.Run("no miners uses the known miners", func(t *testing.T) {
+		client, err := NewClientFromConfig(writeTestConfig(t, "config.json", `{"api_type": "mAPI"}`))
+		require.NoError(t, err)
+		assert.Len(t, client.Miners(), 2)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		client, err := NewClientFromConfig(writeTestConfig(t, "config.json", `{"api_type": "unknown"}`))
+		require.Error(t, err)
+		assert.Nil(t, client)
+	})
+}
+
+// ExampleParseConfig example using ParseConfig()
+func ExampleParseConfig() {
+	config, err := ParseConfig([]byte(`
+api_type: mAPI
+options:
+  request_timeout: 10s
+miners:
+  - name: TestMiner
+    miner_id: "1234567"
+    apis:
+      - type: mAPI
+        url: https://testminer.com
+`), ConfigFormatYAML)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	client, err := config.NewClient()
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("created new client with miner: %s", client.Miners()[0].Name)
+	// Output:created new client with miner: TestMiner
+}
+
+// BenchmarkParseConfig benchmarks the method ParseConfig()
+func BenchmarkParseConfig(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseConfig([]byte(testConfigYAML), ConfigFormatYAML)
+	}
+}
@@ -13,9 +13,13 @@ type Miner struct {
 }
 
 // MinerAPIs is a configuration per miner, including connection url, auth token, etc
+//
+// PreferredAPITypes is an optional list of API types in order of preference, the first
+// type with a configured API is used. If empty, the client API type is used.
 type MinerAPIs struct {
-	MinerID string `json:"miner_id,omitempty"`
-	APIs    []API  `json:"apis,omitempty"`
+	MinerID           string    `json:"miner_id,omitempty"`
+	APIs              []API     `json:"apis,omitempty"`
+	PreferredAPITypes []APIType `json:"preferred_api_types,omitempty"`
 }
 
 // APIType is the type of available APIs
@@ -25,10 +29,15 @@ type APIType string
 type APIActionName string
 
 // API is a configuration per miner, including connection url, auth token, etc
+//
+// RateLimit (requests per second) and RateBurst limit the requests sent with the
+// token to the API, there is no limit if RateLimit is 0.
 type API struct {
-	Type  APIType `json:"type,omitempty"`
-	Token string  `json:"token,omitempty"`
-	URL   string  `json:"url,omitempty"`
+	Type      APIType `json:"type,omitempty"`
+	Token     string  `json:"token,omitempty"`
+	URL       string  `json:"url,omitempty"`
+	RateLimit float64 `json:"rate_limit,omitempty"`
+	RateBurst int     `json:"rate_burst,omitempty"`
 }
 
 // APIRoute contains the routes for a specific API related to a specific action
@@ -0,0 +1,88 @@
+package minercraft
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/libsv/go-bt/v2"
+)
+
+// ErrMissingPreviousOutput is returned when a transaction input is missing the previous
+// output (satoshis & locking script) needed for the Extended Format
+var ErrMissingPreviousOutput = errors.New("missing previous output")
+
+// NewExtendedTransaction will create a Transaction from a bt.Tx that is submitted in
+// Extended Format (BIP-239) to Arc
+//
+// The previous outputs (optional) are set on the inputs in the same order, if they are
+// not provided the inputs must already have PreviousTxSatoshis and PreviousTxScript set.
+// Note: the inputs of the given tx are modified when previous outputs are provided.
+//
+// Specs: https://github.com/bitcoin-sv/arc/blob/main/doc/BIP-239.md
+func NewExtendedTransaction(tx *bt.Tx, previousOutputs ...*bt.Output) (*Transaction, error) {
+	if tx == nil {
+		return nil, errors.New("tx was nil")
+	}
+
+	if len(previousOutputs) > 0 {
+		if len(previousOutputs) != len(tx.Inputs) {
+			return nil, fmt.Errorf(
+				"expected %d previous outputs, got %d", len(tx.Inputs), len(previousOutputs),
+			)
+		}
+		for i, output := range previousOutputs {
+			if output == nil {
+				return nil, fmt.Errorf("input %d: %w", i, ErrMissingPreviousOutput)
+			}
+			tx.Inputs[i].PreviousTxSatoshis = output.Satoshis
+			tx.Inputs[i].PreviousTxScript = output.LockingScript
+		}
+	}
+
+	if err := validateExtendedTx(tx); err != nil {
+		return nil, err
+	}
+
+	return &Transaction{Tx: tx}, nil
+}
+
+// validateExtendedTx will check that every input has the previous output filled in
+func validateExtendedTx(tx *bt.Tx) error {
+	if len(tx.Inputs) == 0 {
+		return errors.New("tx has no inputs")
+	}
+	for i, input := range tx.Inputs {
+		if input.PreviousTxScript == nil {
+			return fmt.Errorf("input %d: %w", i, ErrMissingPreviousOutput)
+		}
+	}
+	return nil
+}
+
+// arcRawTx will return the hex of the transaction to submit to Arc
+//
+// A BEEF package is serialized as BEEF hex, a bt.Tx is serialized in Extended Format,
+// otherwise the RawTx is used as-is.
+func arcRawTx(tx *Transaction) (string, error) {
+	if tx.BEEF != nil {
+		return tx.BEEF.String()
+	}
+	if tx.Tx == nil {
+		return tx.RawTx, nil
+	}
+	if err := validateExtendedTx(tx.Tx); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(tx.Tx.ExtendedBytes()), nil
+}
+
+// mapiTransaction will return the transaction to submit to mAPI
+//
+// mAPI does not support the Extended Format, so a bt.Tx is serialized as a raw tx.
+func mapiTransaction(tx Transaction) Transaction {
+	if tx.Tx != nil && len(tx.RawTx) == 0 {
+		tx.RawTx = tx.Tx.String()
+	}
+	return tx
+}
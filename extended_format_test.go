@@ -0,0 +1,205 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const efTestLockingScript = "76a91482932cf55b847ffa52832d2bbec2838f658f226788ac"
+
+// newTestPreviousOutput will return a previous output for the test tx
+func newTestPreviousOutput(t *testing.T) *bt.Output {
+	script, err := bscript.NewFromHexString(efTestLockingScript)
+	require.NoError(t, err)
+	return &bt.Output{Satoshis: 10000000, LockingScript: script}
+}
+
+// mockHTTPCaptureSubmission for capturing the submitted body
+type mockHTTPCaptureSubmission struct {
+	body    []byte
+	headers http.Header
+}
+
+// Do is a mock http request
+func (m *mockHTTPCaptureSubmission) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		m.body, _ = io.ReadAll(req.Body)
+	}
+	m.headers = req.Header
+
+	// Arc response
+	if strings.HasSuffix(req.URL.Path, "/v1/tx") {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(bytes.NewBufferString(
+				`{"status":200,"title":"OK","txStatus":"SEEN_ON_NETWORK","txid":"` + testTx + `"}`,
+			)),
+		}, nil
+	}
+
+	if strings.HasSuffix(req.URL.Path, "/v1/txs") {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(bytes.NewBufferString(
+				`[{"status":200,"title":"OK","txStatus":"SEEN_ON_NETWORK","txid":"` + testTx + `"}]`,
+			)),
+		}, nil
+	}
+
+	// mAPI response
+	return (&mockHTTPValidSubmission{}).Do(req)
+}
+
+// TestNewExtendedTransaction tests the method NewExtendedTransaction()
+func TestNewExtendedTransaction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid previous outputs", func(t *testing.T) {
+		tx, err := bt.NewTxFromString(rawTx)
+		require.NoError(t, err)
+
+		var transaction *Transaction
+		transaction, err = NewExtendedTransaction(tx, newTestPreviousOutput(t))
+		require.NoError(t, err)
+		require.NotNil(t, transaction)
+
+		assert.Equal(t, uint64(10000000), transaction.Tx.Inputs[0].PreviousTxSatoshis)
+		assert.Equal(t, efTestLockingScript, transaction.Tx.Inputs[0].PreviousTxScript.String())
+	})
+
+	t.Run("previous outputs already set", func(t *testing.T) {
+		tx, err := bt.NewTxFromString(rawTx)
+		require.NoError(t, err)
+		tx.Inputs[0].PreviousTxSatoshis = 1000
+		tx.Inputs[0].PreviousTxScript = newTestPreviousOutput(t).LockingScript
+
+		_, err = NewExtendedTransaction(tx)
+		require.NoError(t, err)
+	})
+
+	t.Run("missing previous outputs", func(t *testing.T) {
+		tx, err := bt.NewTxFromString(rawTx)
+		require.NoError(t, err)
+
+		_, err = NewExtendedTransaction(tx)
+		require.ErrorIs(t, err, ErrMissingPreviousOutput)
+	})
+
+	t.Run("nil previous output", func(t *testing.T) {
+		tx, err := bt.NewTxFromString(rawTx)
+		require.NoError(t, err)
+
+		_, err = NewExtendedTransaction(tx, nil)
+		require.ErrorIs(t, err, ErrMissingPreviousOutput)
+	})
+
+	t.Run("wrong number of previous outputs", func(t *testing.T) {
+		tx, err := bt.NewTxFromString(rawTx)
+		require.NoError(t, err)
+
+		_, err = NewExtendedTransaction(tx, newTestPreviousOutput(t), newTestPreviousOutput(t))
+		require.Error(t, err)
+	})
+
+	t.Run("nil tx", func(t *testing.T) {
+		_, err := NewExtendedTransaction(nil)
+		require.Error(t, err)
+	})
+}
+
+// TestClient_SubmitTransaction_ExtendedFormat tests submitting a bt.Tx
+func TestClient_SubmitTransaction_ExtendedFormat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("arc receives the extended format", func(t *testing.T) {
+		tx, err := bt.NewTxFromString(rawTx)
+		require.NoError(t, err)
+		var transaction *Transaction
+		transaction, err = NewExtendedTransaction(tx, newTestPreviousOutput(t))
+		require.NoError(t, err)
+
+		httpClient := &mockHTTPCaptureSubmission{}
+		client := newTestArcClient(httpClient)
+		_, err = client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), transaction)
+		require.NoError(t, err)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(httpClient.body, &body))
+		assert.Equal(t, "01000000"+"0000000000ef", body["rawTx"][:20])
+		assert.Equal(t, tx.ExtendedBytes(), mustDecodeHex(t, body["rawTx"]))
+	})
+
+	t.Run("arc rejects missing previous outputs", func(t *testing.T) {
+		tx, err := bt.NewTxFromString(rawTx)
+		require.NoError(t, err)
+
+		client := newTestArcClient(&mockHTTPCaptureSubmission{})
+		_, err = client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), &Transaction{Tx: tx})
+		require.ErrorIs(t, err, ErrMissingPreviousOutput)
+	})
+
+	t.Run("arc batch receives the extended format", func(t *testing.T) {
+		tx, err := bt.NewTxFromString(rawTx)
+		require.NoError(t, err)
+		var transaction *Transaction
+		transaction, err = NewExtendedTransaction(tx, newTestPreviousOutput(t))
+		require.NoError(t, err)
+
+		httpClient := &mockHTTPCaptureSubmission{}
+		client := newTestArcClient(httpClient)
+		_, err = client.SubmitTransactions(context.Background(), client.MinerByName(MinerGorillaPool), []Transaction{*transaction})
+		require.NoError(t, err)
+		assert.Contains(t, string(httpClient.body), fmt.Sprintf("%x", tx.ExtendedBytes()))
+	})
+
+	t.Run("mapi receives the raw tx", func(t *testing.T) {
+		tx, err := bt.NewTxFromString(rawTx)
+		require.NoError(t, err)
+		var transaction *Transaction
+		transaction, err = NewExtendedTransaction(tx, newTestPreviousOutput(t))
+		require.NoError(t, err)
+
+		httpClient := &mockHTTPCaptureSubmission{}
+		client := newTestClient(httpClient)
+		_, err = client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), transaction)
+		require.NoError(t, err)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(httpClient.body, &body))
+		assert.Equal(t, rawTx, body["rawtx"])
+	})
+}
+
+// ExampleNewExtendedTransaction example using NewExtendedTransaction()
+func ExampleNewExtendedTransaction() {
+	tx, _ := bt.NewTxFromString(rawTx)
+	script, _ := bscript.NewFromHexString(efTestLockingScript)
+
+	transaction, err := NewExtendedTransaction(tx, &bt.Output{Satoshis: 10000000, LockingScript: script})
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("previous satoshis: %d", transaction.Tx.Inputs[0].PreviousTxSatoshis)
+	// Output:previous satoshis: 10000000
+}
+
+// mustDecodeHex will decode a hex string or fail the test
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
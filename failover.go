@@ -0,0 +1,122 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+)
+
+// ErrAllMinersFailed is returned by SubmitWithFailover when no miner could take the transaction
+var ErrAllMinersFailed = errors.New("all miners failed")
+
+// FailoverAttempt is the submission of the transaction to one miner
+type FailoverAttempt struct {
+	Error    error                      `json:"error,omitempty"` // Why the miner failed (nil for the final miner if it answered)
+	Miner    *Miner                     `json:"miner"`
+	Response *SubmitTransactionResponse `json:"response,omitempty"`
+}
+
+// FailoverResponse is the response of SubmitWithFailover
+type FailoverResponse struct {
+	Attempts []*FailoverAttempt         `json:"attempts"` // Miners that were tried (in order)
+	Miner    *Miner                     `json:"miner"`    // Miner that answered with a response (nil otherwise, see Attempts)
+	Response *SubmitTransactionResponse `json:"response"` // Response of the miner that answered
+}
+
+// SubmitWithFailover will submit the transaction to the miners in the given order (defaults to all
+// the client miners) until one of them answers
+//
+// The next miner is only tried on a retryable error (see IsRetryable), a transport error, a mAPI
+// failure marked as FailureRetryable, or when the miner has no usable API (see MinerAPIByMinerID).
+// A definitive answer (success or rejection, ex: invalid script) stops the failover: rejections
+// returned as an error by the miner are returned, rejections in the response are in the Response.
+// The Miner is only set when a miner answered with a response.
+// If all the miners failed, ErrAllMinersFailed is returned along with the attempts.
+func (c *Client) SubmitWithFailover(ctx context.Context, tx *Transaction, miners []*Miner,
+	opts ...SubmitTransactionOptFunc) (*FailoverResponse, error) {
+
+	// Make sure we have a valid transaction
+	if tx == nil {
+		return nil, errors.New("transaction was nil")
+	}
+
+	if len(miners) == 0 {
+		miners = c.minersSnapshot()
+	}
+
+	response := new(FailoverResponse)
+	var lastErr error
+	for _, miner := range miners {
+		if miner == nil {
+			continue
+		}
+
+		attempt := &FailoverAttempt{Miner: miner}
+		response.Attempts = append(response.Attempts, attempt)
+		attempt.Response, attempt.Error = c.SubmitTransaction(ctx, miner, tx, opts...)
+
+		if attempt.Error == nil {
+			attempt.Error = retryableRejection(attempt.Response)
+		}
+
+		// Definitive answer (or the caller gave up)
+		if attempt.Error == nil || !isFailoverError(attempt.Error) || ctx.Err() != nil {
+			if attempt.Response == nil {
+				return response, attempt.Error
+			}
+			response.Miner = miner
+			response.Response = attempt.Response
+			return response, nil
+		}
+		lastErr = attempt.Error
+	}
+
+	if lastErr == nil {
+		return response, ErrAllMinersFailed
+	}
+	return response, allMinersFailedError{err: lastErr}
+}
+
+// allMinersFailedError is ErrAllMinersFailed with the error of the last miner
+type allMinersFailedError struct {
+	err error
+}
+
+// Error returns the error message
+func (e allMinersFailedError) Error() string {
+	return ErrAllMinersFailed.Error() + ": " + e.err.Error()
+}
+
+// Is returns true for ErrAllMinersFailed
+func (e allMinersFailedError) Is(target error) bool {
+	return errors.Is(ErrAllMinersFailed, target)
+}
+
+// Unwrap returns the error of the last miner
+func (e allMinersFailedError) Unwrap() error {
+	return e.err
+}
+
+// retryableRejection will return a retryable error if mAPI rejected the transaction with FailureRetryable
+func retryableRejection(response *SubmitTransactionResponse) error {
+	if response == nil || response.Results == nil || response.APIType != MAPI {
+		return nil
+	}
+	if response.Results.ReturnResult != QueryTransactionFailure || !response.Results.FailureRetryable {
+		return nil
+	}
+	return ErrRetryable{err: errors.New(response.Results.ResultDescription)}
+}
+
+// isFailoverError will return true if the transaction can be submitted to the next miner
+func isFailoverError(err error) bool {
+	var apiErr *APINotFoundError
+	var urlErr *url.Error
+	var netErr net.Error
+	return IsRetryable(err) ||
+		errors.Is(err, ErrBEEFNotSupported) ||
+		errors.As(err, &apiErr) ||
+		errors.As(err, &urlErr) ||
+		errors.As(err, &netErr)
+}
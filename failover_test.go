@@ -0,0 +1,206 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHTTPByHost for mocking the responses of each miner (the others use the fallback or are valid submissions)
+type mockHTTPByHost struct {
+	fallback  HTTPInterface
+	responses map[string]mockResponse
+}
+
+// Do is a mock http request
+func (m *mockHTTPByHost) Do(req *http.Request) (*http.Response, error) {
+	for host, response := range m.responses {
+		if strings.Contains(req.URL.Host, host) {
+			return (&mockHTTPSequence{responses: []mockResponse{response}}).Do(req)
+		}
+	}
+	if m.fallback != nil {
+		return m.fallback.Do(req)
+	}
+	return (&mockHTTPValidSubmission{}).Do(req)
+}
+
+// newTestFailoverClient returns a client using the default HTTP client for the given miner urls
+func newTestFailoverClient(t *testing.T, urls ...string) *Client {
+	miners := make([]*Miner, 0, len(urls))
+	apis := make([]*MinerAPIs, 0, len(urls))
+	for i, minerURL := range urls {
+		minerID := "miner-" + string(rune('a'+i))
+		miners = append(miners, &Miner{MinerID: minerID, Name: minerID})
+		apis = append(apis, &MinerAPIs{MinerID: minerID, APIs: []API{{Type: MAPI, URL: minerURL}}})
+	}
+
+	options := DefaultClientOptions()
+	options.RetryPolicy = testRetryPolicy()
+	options.RetryPolicy.MaxRetries = 0
+	client, err := createClient(options, MAPI, nil, miners, apis)
+	require.NoError(t, err)
+	return client
+}
+
+// refusedURL returns the url of a closed local port (connections are refused)
+func refusedURL(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return "http://" + address
+}
+
+// TestClient_SubmitWithFailover tests the method SubmitWithFailover()
+func TestClient_SubmitWithFailover(t *testing.T) {
+	t.Parallel()
+
+	tx := &Transaction{RawTx: submitTestExampleTx}
+
+	t.Run("first miner accepts", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		response, err := client.SubmitWithFailover(context.Background(), tx, nil)
+		require.NoError(t, err)
+		require.Len(t, response.Attempts, 1)
+		assert.Equal(t, MinerTaal, response.Miner.Name)
+		assert.Equal(t, QueryTransactionSuccess, response.Response.Results.ReturnResult)
+	})
+
+	t.Run("fails over on a retryable error", func(t *testing.T) {
+		client := newTestClient(&mockHTTPByHost{responses: map[string]mockResponse{
+			"taal": {statusCode: http.StatusBadGateway},
+		}})
+		response, err := client.SubmitWithFailover(context.Background(), tx, nil)
+		require.NoError(t, err)
+		require.Len(t, response.Attempts, 2)
+		assert.True(t, IsRetryable(response.Attempts[0].Error))
+		assert.Equal(t, MinerGorillaPool, response.Miner.Name)
+	})
+
+	t.Run("fails over on a transport error", func(t *testing.T) {
+		client := newTestClient(&mockHTTPByHost{responses: map[string]mockResponse{
+			"taal": {err: &netTimeoutError{}},
+		}})
+		response, err := client.SubmitWithFailover(context.Background(), tx, nil)
+		require.NoError(t, err)
+		require.Len(t, response.Attempts, 2)
+		assert.Equal(t, MinerGorillaPool, response.Miner.Name)
+	})
+
+	t.Run("fails over on a transport error of the default client", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			resp, _ := (&mockHTTPValidSubmission{}).Do(req)
+			w.WriteHeader(resp.StatusCode)
+			if resp.Body != nil {
+				_, _ = io.Copy(w, resp.Body)
+			}
+		}))
+		defer server.Close()
+
+		client := newTestFailoverClient(t, refusedURL(t), server.URL)
+		response, err := client.SubmitWithFailover(context.Background(), tx, nil)
+		require.NoError(t, err)
+		require.Len(t, response.Attempts, 2)
+		assert.True(t, IsRetryable(response.Attempts[0].Error))
+		assert.Equal(t, "miner-b", response.Miner.Name)
+		assert.Equal(t, QueryTransactionSuccess, response.Response.Results.ReturnResult)
+	})
+
+	t.Run("all miners refuse the connection", func(t *testing.T) {
+		client := newTestFailoverClient(t, refusedURL(t), refusedURL(t))
+		response, err := client.SubmitWithFailover(context.Background(), tx, nil)
+		require.ErrorIs(t, err, ErrAllMinersFailed)
+		require.Len(t, response.Attempts, 2)
+		assert.Nil(t, response.Miner)
+	})
+
+	t.Run("fails over on a retryable rejection", func(t *testing.T) {
+		client := newTestClient(&mockHTTPByHost{responses: map[string]mockResponse{
+			"taal": {statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionFailure, true)},
+		}})
+		response, err := client.SubmitWithFailover(context.Background(), tx, nil)
+		require.NoError(t, err)
+		require.Len(t, response.Attempts, 2)
+		assert.True(t, IsRetryable(response.Attempts[0].Error))
+		assert.NotNil(t, response.Attempts[0].Response)
+	})
+
+	t.Run("stops on a definitive rejection", func(t *testing.T) {
+		client := newTestClient(&mockHTTPByHost{responses: map[string]mockResponse{
+			"taal": {statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionFailure, false)},
+		}})
+		response, err := client.SubmitWithFailover(context.Background(), tx, nil)
+		require.NoError(t, err)
+		require.Len(t, response.Attempts, 1)
+		assert.Equal(t, QueryTransactionFailure, response.Response.Results.ReturnResult)
+	})
+
+	t.Run("stops on a bad request", func(t *testing.T) {
+		client := newTestClient(&mockHTTPByHost{responses: map[string]mockResponse{
+			"taal": {statusCode: http.StatusBadRequest, body: `{"title":"invalid script"}`},
+		}})
+		response, err := client.SubmitWithFailover(context.Background(), tx, nil)
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, ErrAllMinersFailed))
+		require.Len(t, response.Attempts, 1)
+		assert.Equal(t, MinerTaal, response.Attempts[0].Miner.Name)
+		assert.Nil(t, response.Miner)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		client := newTestClient(&mockHTTPCancel{cancel: cancel})
+		response, err := client.SubmitWithFailover(ctx, tx, nil)
+		require.Error(t, err)
+		require.Len(t, response.Attempts, 1)
+		assert.Nil(t, response.Miner)
+		assert.Nil(t, response.Response)
+	})
+
+	t.Run("skips miners without an api", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		unknown := &Miner{MinerID: "unknown", Name: "Unknown"}
+		response, err := client.SubmitWithFailover(context.Background(), tx,
+			[]*Miner{unknown, client.MinerByName(MinerGorillaPool)})
+		require.NoError(t, err)
+		require.Len(t, response.Attempts, 2)
+		var apiErr *APINotFoundError
+		assert.ErrorAs(t, response.Attempts[0].Error, &apiErr)
+		assert.Equal(t, MinerGorillaPool, response.Miner.Name)
+	})
+
+	t.Run("all miners failed", func(t *testing.T) {
+		client := newTestClient(&mockHTTPByHost{responses: map[string]mockResponse{
+			"taal":        {statusCode: http.StatusServiceUnavailable},
+			"gorillapool": {statusCode: http.StatusServiceUnavailable},
+		}})
+		response, err := client.SubmitWithFailover(context.Background(), tx, nil)
+		require.ErrorIs(t, err, ErrAllMinersFailed)
+		assert.True(t, IsRetryable(err))
+		assert.True(t, strings.HasPrefix(err.Error(), ErrAllMinersFailed.Error()+": "))
+		require.Len(t, response.Attempts, 2)
+		assert.Nil(t, response.Miner)
+	})
+
+	t.Run("nil transaction", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		_, err := client.SubmitWithFailover(context.Background(), nil, nil)
+		require.Error(t, err)
+	})
+}
+
+// netTimeoutError is a net.Error for the tests
+type netTimeoutError struct{}
+
+func (e *netTimeoutError) Error() string   { return "i/o timeout" }
+func (e *netTimeoutError) Timeout() bool   { return true }
+func (e *netTimeoutError) Temporary() bool { return true }
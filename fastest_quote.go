@@ -11,6 +11,10 @@ import (
 //
 // Note: this might return different results each time if miners have the same rates as
 // it's a race condition on which results come back first
+//
+// If the health monitor is running, no quote is requested and the timeout is ignored: the
+// quote recorded for the healthy miner with the highest score is returned instead (the score
+// includes the latency, see StartHealthMonitor and MinerScore). The quote is a copy.
 func (c *Client) FastestQuote(ctx context.Context, timeout time.Duration) (*FeeQuoteResponse, error) {
 
 	// No timeout (use the default)
@@ -18,6 +22,11 @@ func (c *Client) FastestQuote(ctx context.Context, timeout time.Duration) (*FeeQ
 		timeout = defaultFastQuoteTimeout
 	}
 
+	// Use the quote of the healthiest miner (if the health monitor is running)
+	if quotes := c.monitoredQuotes(); len(quotes) > 0 {
+		return quotes[0], nil
+	}
+
 	// Get the fastest quote
 	result := c.fetchFastestQuote(ctx, timeout)
 	if result == nil {
@@ -42,8 +51,11 @@ func (c *Client) FastestQuote(ctx context.Context, timeout time.Duration) (*FeeQ
 // fetchFastestQuote will return a quote that is the quickest to resolve
 func (c *Client) fetchFastestQuote(ctx context.Context, timeout time.Duration) *internalResult {
 
+	// Skip the miners with an open circuit breaker
+	miners := c.availableMiners(c.minersSnapshot(), "")
+
 	// The channel for the internal results
-	resultsChannel := make(chan *internalResult, len(c.miners))
+	resultsChannel := make(chan *internalResult, len(miners))
 
 	// Create a context (to cancel or timeout)
 	ctxWithCancel, cancel := context.WithTimeout(ctx, timeout)
@@ -51,11 +63,11 @@ func (c *Client) fetchFastestQuote(ctx context.Context, timeout time.Duration) *
 
 	// Loop each miner (break into a Go routine for each quote request)
 	var wg sync.WaitGroup
-	for _, miner := range c.miners {
+	for _, miner := range miners {
 		wg.Add(1)
 		go func(ctx2 context.Context, wg *sync.WaitGroup, client *Client, miner *Miner) {
 			defer wg.Done()
-			res := getQuote(ctx2, client, miner, mAPIRouteFeeQuote)
+			res := getQuote(ctx2, client, miner, FeeQuote)
 			if res.Response.Error == nil {
 				resultsChannel <- res
 			}
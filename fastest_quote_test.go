@@ -215,6 +215,29 @@ func TestClient_FastestQuote(t *testing.T) {
 
 }
 
+// TestClient_FastestQuote_Arc tests the method FastestQuote() using Arc
+func TestClient_FastestQuote_Arc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get a valid fastest quote", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPValidArcFeeQuote{})
+
+		response, err := client.FastestQuote(context.Background(), defaultFastQuoteTimeout)
+		require.NoError(t, err)
+		require.NotNil(t, response)
+
+		assert.Equal(t, Arc, response.APIType)
+		assert.Len(t, response.Quote.Fees, 2)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPBadRequest{})
+		response, err := client.FastestQuote(context.Background(), defaultFastQuoteTimeout)
+		require.Error(t, err)
+		assert.Nil(t, response)
+	})
+}
+
 // ExampleClient_FastestQuote example using FastestQuote()
 func ExampleClient_FastestQuote() {
 	// Create a client (using a test client vs NewClient())
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/libsv/go-bt/v2"
+	"github.com/tonicpow/go-minercraft/v2/apis/arc"
 	"github.com/tonicpow/go-minercraft/v2/apis/mapi"
 )
 
@@ -20,13 +21,17 @@ type FeeQuoteResponse struct {
 	Quote *mapi.FeePayload `json:"quote"` // Custom field for unmarshalled payload data
 }
 
-// FeeQuote will fire a Merchant API request to retrieve the fees from a given miner
+// FeeQuote will fire a Merchant&Arc API request to retrieve the fees from a given miner
 //
 // This endpoint is used to get the different fees quoted by a miner.
 // It returns a JSONEnvelope with a payload that contains the fees charged by a specific BSV miner.
 // The purpose of the envelope is to ensure strict consistency in the message content for the purpose of signing responses.
 //
+// Arc does not have a fee quote endpoint, the fees are taken from the policy (miningFee)
+// and returned as standard and data fees.
+//
 // Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi#2-get-fee-quote
+// Specs: https://docs.gorillapool.io/arc/api.html#get-the-policy-settings
 func (c *Client) FeeQuote(ctx context.Context, miner *Miner) (*FeeQuoteResponse, error) {
 
 	// Make sure we have a valid miner
@@ -35,7 +40,7 @@ func (c *Client) FeeQuote(ctx context.Context, miner *Miner) (*FeeQuoteResponse,
 	}
 
 	// Make the HTTP request
-	result := getQuote(ctx, c, miner, mAPIRouteFeeQuote)
+	result := getQuote(ctx, c, miner, FeeQuote)
 	if result.Response.Error != nil {
 		return nil, result.Response.Error
 	}
@@ -64,6 +69,7 @@ func (c *Client) FeeQuote(ctx context.Context, miner *Miner) (*FeeQuoteResponse,
 
 // internalResult is a shim for storing miner & http response data
 type internalResult struct {
+	APIType  APIType
 	Response *RequestResponse
 	Miner    *Miner
 }
@@ -71,6 +77,11 @@ type internalResult struct {
 // parseFeeQuote will convert the HTTP response into a struct and also unmarshal the payload JSON data
 func (i *internalResult) parseFeeQuote() (response FeeQuoteResponse, err error) {
 
+	// Arc returns the fees as part of the policy
+	if i.APIType == Arc {
+		return i.parseArcFeeQuote()
+	}
+
 	// Process the initial response payload
 	if err = response.process(i.Miner, i.Response.BodyContents); err != nil {
 		return
@@ -94,6 +105,38 @@ func (i *internalResult) parseFeeQuote() (response FeeQuoteResponse, err error)
 	return
 }
 
+// parseArcFeeQuote will convert the Arc policy HTTP response into a fee quote response
+func (i *internalResult) parseArcFeeQuote() (response FeeQuoteResponse, err error) {
+	response.Miner = i.Miner
+	response.APIType = Arc
+
+	model := new(arc.PolicyQuoteModel)
+	if err = json.Unmarshal(i.Response.BodyContents, model); err != nil {
+		return
+	}
+
+	response.Quote = new(mapi.FeePayload)
+	arcPolicyIntoQuote(model, response.Quote)
+	return
+}
+
+// arcPolicyIntoQuote will convert the Arc policy into a final quote payload
+func arcPolicyIntoQuote(model *arc.PolicyQuoteModel, quote *mapi.FeePayload) {
+	quote.Timestamp = model.Timestamp
+
+	// Arc has a single fee for all bytes, use it for both fee types
+	if model.Policy.MiningFee == nil {
+		return
+	}
+	for _, t := range []bt.FeeType{bt.FeeTypeStandard, bt.FeeTypeData} {
+		quote.Fees = append(quote.Fees, &bt.Fee{
+			FeeType:   t,
+			MiningFee: model.Policy.MiningFee.MiningFee,
+			RelayFee:  model.Policy.MiningFee.RelayFee,
+		})
+	}
+}
+
 // rawPayloadIntoQuote will convert the raw parsed payload into a final quote payload
 func rawPayloadIntoQuote(payload *mapi.RawFeePayload, quote *mapi.FeePayload) {
 
@@ -127,10 +170,18 @@ func rawPayloadIntoQuote(payload *mapi.RawFeePayload, quote *mapi.FeePayload) {
 }
 
 // getQuote will fire the HTTP request to retrieve the fee/policy quote
-func getQuote(ctx context.Context, client *Client, miner *Miner, route string) (result *internalResult) {
+func getQuote(ctx context.Context, client *Client, miner *Miner, actionName APIActionName) (result *internalResult) {
 	sb := strings.Builder{}
 
-	api, err := client.MinerAPIByMinerID(miner.MinerID, client.apiType)
+	api, err := client.minerAPI(miner, "")
+	if err != nil {
+		result = &internalResult{
+			Response: &RequestResponse{Error: err},
+		}
+		return
+	}
+
+	route, err := ActionRouteByAPIType(actionName, api.Type)
 	if err != nil {
 		result = &internalResult{
 			Response: &RequestResponse{Error: err},
@@ -139,17 +190,13 @@ func getQuote(ctx context.Context, client *Client, miner *Miner, route string) (
 	}
 
 	sb.WriteString(api.URL + route)
-	result = &internalResult{Miner: miner}
+	result = &internalResult{APIType: api.Type, Miner: miner}
 	quoteURL, err := url.Parse(sb.String())
 	if err != nil {
 		result.Response = &RequestResponse{Error: err}
 		return
 	}
 
-	result.Response = httpRequest(ctx, client, &httpPayload{
-		Method: http.MethodGet,
-		URL:    quoteURL.String(),
-		Token:  api.Token,
-	})
+	result.Response = httpRequest(ctx, client, newAPIPayload(api, http.MethodGet, quoteURL.String()))
 	return
 }
@@ -44,6 +44,39 @@ func (m *mockHTTPValidFeeQuote) Do(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+const (
+	policyURLTaalArc        = "https://tapi.taal.com/arc/v1/policy"
+	policyURLGorillaPoolArc = "https://arc.gorillapool.io/v1/policy"
+)
+
+// mockHTTPValidArcFeeQuote for mocking requests
+type mockHTTPValidArcFeeQuote struct{}
+
+// Do is a mock http request
+func (m *mockHTTPValidArcFeeQuote) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusBadRequest
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	// Valid response
+	if req.URL.String() == policyURLTaalArc {
+		resp.StatusCode = http.StatusOK
+		resp.Body = io.NopCloser(bytes.NewBufferString(`{"policy":{"maxscriptsizepolicy":100000000,"maxtxsigopscount":4294967295,"maxtxsizepolicy":100000000,"miningFee":{"bytes":1000,"satoshis":50}},"timestamp":"2023-08-10T13:49:07.308687569Z"}`))
+	}
+
+	if req.URL.String() == policyURLGorillaPoolArc {
+		resp.StatusCode = http.StatusOK
+		resp.Body = io.NopCloser(bytes.NewBufferString(`{"policy":{"maxscriptsizepolicy":100000000,"maxtxsigopscount":4294967295,"maxtxsizepolicy":100000000,"miningFee":{"bytes":1000,"satoshis":1}},"timestamp":"2023-08-10T13:49:07.308687569Z"}`))
+	}
+
+	// Default is valid
+	return resp, nil
+}
+
 // mockHTTPError for mocking requests
 type mockHTTPError struct{}
 
@@ -381,6 +414,62 @@ func TestClient_FeeQuote(t *testing.T) {
 	})
 }
 
+// TestClient_FeeQuote_Arc tests the method FeeQuote() using Arc
+func TestClient_FeeQuote_Arc(t *testing.T) {
+
+	t.Run("get a valid fee quote", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPValidArcFeeQuote{})
+
+		response, err := client.FeeQuote(context.Background(), client.MinerByName(MinerTaal))
+		require.NoError(t, err)
+		require.NotNil(t, response)
+
+		assert.True(t, response.Validated)
+		assert.Equal(t, Arc, response.APIType)
+		assert.Equal(t, MinerTaal, response.Miner.Name)
+		assert.Equal(t, "2023-08-10T13:49:07.308687569Z", response.Quote.Timestamp)
+		assert.Len(t, response.Quote.Fees, 2)
+		assert.Equal(t, 50, response.Quote.GetFee(mapi.FeeTypeStandard).MiningFee.Satoshis)
+		assert.Equal(t, 1000, response.Quote.GetFee(mapi.FeeTypeData).MiningFee.Bytes)
+	})
+
+	t.Run("get actual rates", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPValidArcFeeQuote{})
+
+		response, err := client.FeeQuote(context.Background(), client.MinerByName(MinerTaal))
+		require.NoError(t, err)
+		require.NotNil(t, response)
+
+		var rate uint64
+		rate, err = response.Quote.CalculateFee(mapi.FeeCategoryMining, mapi.FeeTypeStandard, 1000)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50), rate)
+
+		rate, err = response.Quote.CalculateFee(mapi.FeeCategoryRelay, mapi.FeeTypeData, 1000)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50), rate)
+	})
+
+	t.Run("missing fees", func(t *testing.T) {
+		client := newTestArcClient(&MockClient{MockDo: func(*http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"policy":{"maxtxsizepolicy":100000000},"timestamp":"2023-08-10T13:49:07.308687569Z"}`)),
+			}, nil
+		}})
+		response, err := client.FeeQuote(context.Background(), client.MinerByName(MinerTaal))
+		require.Error(t, err)
+		assert.Nil(t, response)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPInvalidJSON{})
+		response, err := client.FeeQuote(context.Background(), client.MinerByName(MinerTaal))
+		require.Error(t, err)
+		assert.Nil(t, response)
+	})
+}
+
 // ExampleClient_FeeQuote example using FeeQuote()
 func ExampleClient_FeeQuote() {
 	// Create a client (using a test client vs NewClient())
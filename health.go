@@ -0,0 +1,80 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/tonicpow/go-minercraft/v2/apis/arc"
+)
+
+// HealthResponse is the health status of a miner API
+type HealthResponse struct {
+	APIType APIType       `json:"apiType"`
+	Healthy bool          `json:"healthy"`
+	Latency time.Duration `json:"latency"`           // Duration of the health request
+	Miner   *Miner        `json:"miner"`             // Miner that was checked
+	Reason  string        `json:"reason,omitempty"`  // Reason the miner is unhealthy (or the Arc reason)
+	Version string        `json:"version,omitempty"` // Version of the API (Arc only)
+}
+
+// Health will check if the miner API is healthy
+//
+// Arc is checked using the health endpoint, mAPI does not have one so a fee quote
+// is requested instead. A failed request is not returned as an error but as an
+// unhealthy response with the Reason, errors are only returned for invalid miners.
+//
+// Specs: https://docs.gorillapool.io/arc/api.html
+func (c *Client) Health(ctx context.Context, miner *Miner) (*HealthResponse, error) {
+
+	// Make sure we have a valid miner
+	if miner == nil {
+		return nil, errors.New("miner was nil")
+	}
+
+	api, err := c.minerAPI(miner, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	start := time.Now()
+	result := getQuote(ctx, c, miner, Health)
+	response := &HealthResponse{
+		APIType: api.Type,
+		Latency: time.Since(start),
+		Miner:   miner,
+	}
+
+	if result.Response.Error != nil {
+		response.Reason = result.Response.Error.Error()
+		return response, nil
+	}
+
+	switch api.Type {
+	case Arc:
+		model := new(arc.HealthModel)
+		if err = json.Unmarshal(result.Response.BodyContents, model); err != nil {
+			response.Reason = "failed to unmarshal health response: " + err.Error()
+			return response, nil
+		}
+		response.Healthy = model.Healthy
+		response.Reason = model.Reason
+		response.Version = model.Version
+	default:
+		// Any valid fee quote means mAPI is healthy
+		quote, parseErr := result.parseFeeQuote()
+		if parseErr != nil {
+			response.Reason = "failed to parse fee quote: " + parseErr.Error()
+			return response, nil
+		}
+		if quote.Quote == nil || len(quote.Quote.Fees) == 0 {
+			response.Reason = "no fees in fee quote"
+			return response, nil
+		}
+		response.Healthy = true
+	}
+
+	return response, nil
+}
@@ -0,0 +1,393 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libsv/go-bt/v2"
+)
+
+// ErrHealthMonitorRunning is returned when the health monitor is already running
+var ErrHealthMonitorRunning = errors.New("health monitor is already running")
+
+const (
+	// defaultHealthMonitorInterval is the default time between the probes of the miners
+	defaultHealthMonitorInterval = 30 * time.Second
+
+	// defaultHealthMonitorWindow is the default number of probes kept per miner
+	defaultHealthMonitorWindow = 10
+)
+
+// HealthMonitorOptions are the options of the health monitor (see StartHealthMonitor)
+type HealthMonitorOptions struct {
+	Interval time.Duration `json:"interval"` // Time between the probes of the miners
+	Timeout  time.Duration `json:"timeout"`  // Timeout of a probe (defaults to the interval)
+	Window   int           `json:"window"`   // Number of probes used for the error rate and latency
+}
+
+// MinerScore is the health of a miner recorded by the health monitor
+//
+// The Score is between 0 (unusable) and 1, it's lowered by the error rate, the latency
+// and the number of blocks the miner is behind the other miners.
+type MinerScore struct {
+	BlockHeight uint64            `json:"blockHeight"` // Last CurrentHighestBlockHeight (mAPI only)
+	BlockLag    uint64            `json:"blockLag"`    // Blocks behind the highest miner
+	ErrorRate   float64           `json:"errorRate"`   // Failed probes in the window (0 to 1)
+	Healthy     bool              `json:"healthy"`     // Last probe succeeded
+	LastChecked time.Time         `json:"lastChecked"`
+	Latency     time.Duration     `json:"latency"` // Average latency of the successful probes in the window
+	Miner       *Miner            `json:"miner"`
+	Probes      int               `json:"probes"` // Probes in the window
+	Quote       *FeeQuoteResponse `json:"quote,omitempty"`
+	Score       float64           `json:"score"`
+}
+
+// healthProbe is the result of a probe
+type healthProbe struct {
+	failed  bool
+	latency time.Duration
+}
+
+// minerHealth is the recorded health of a miner
+type minerHealth struct {
+	blockHeight uint64
+	lastChecked time.Time
+	miner       *Miner
+	probes      []healthProbe
+	quote       *FeeQuoteResponse
+}
+
+// healthMonitor probes the miners in the background
+type healthMonitor struct {
+	sync.RWMutex
+	cancel  context.CancelFunc
+	done    chan struct{}
+	miners  map[string]*minerHealth // By miner ID
+	options HealthMonitorOptions
+}
+
+// newHealthMonitor will return a stopped health monitor
+func newHealthMonitor() *healthMonitor {
+	return &healthMonitor{
+		miners:  make(map[string]*minerHealth),
+		options: HealthMonitorOptions{Interval: defaultHealthMonitorInterval, Window: defaultHealthMonitorWindow},
+	}
+}
+
+// StartHealthMonitor will start probing all the miners in the background with a fee quote
+// (see MinerScore)
+//
+// While the monitor is running, BestQuote and FastestQuote use the quotes of the healthy miners
+// instead of requesting them: FastestQuote returns the quote of the miner with the highest score
+// (which includes the latency) and ignores its timeout. The returned quotes are copies.
+// The monitor stops with StopHealthMonitor or when the context is done.
+func (c *Client) StartHealthMonitor(ctx context.Context, opts *HealthMonitorOptions) error {
+	m := c.healthMonitor
+	m.Lock()
+	defer m.Unlock()
+	if m.cancel != nil {
+		return ErrHealthMonitorRunning
+	}
+
+	if opts != nil {
+		m.options = *opts
+	}
+	if m.options.Interval <= 0 {
+		m.options.Interval = defaultHealthMonitorInterval
+	}
+	if m.options.Timeout <= 0 {
+		m.options.Timeout = m.options.Interval
+	}
+	if m.options.Window <= 0 {
+		m.options.Window = defaultHealthMonitorWindow
+	}
+
+	var monitorCtx context.Context
+	monitorCtx, m.cancel = context.WithCancel(ctx)
+	m.done = make(chan struct{})
+	go c.runHealthMonitor(monitorCtx, m.options, m.done)
+	return nil
+}
+
+// StopHealthMonitor will stop the health monitor (the recorded scores are kept)
+func (c *Client) StopHealthMonitor() {
+	m := c.healthMonitor
+	m.Lock()
+	cancel, done := m.cancel, m.done
+	m.cancel, m.done = nil, nil
+	m.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// runHealthMonitor will probe the miners until the context is done
+func (c *Client) runHealthMonitor(ctx context.Context, opts HealthMonitorOptions, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+		c.ProbeMiners(probeCtx)
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProbeMiners will probe all the miners once with a fee quote and record their health
+//
+// It's called by the health monitor, it can also be used to record the health on demand.
+func (c *Client) ProbeMiners(ctx context.Context) {
+	miners := c.minersSnapshot()
+	c.healthMonitor.prune(miners)
+
+	var wg sync.WaitGroup
+	for _, miner := range miners {
+		wg.Add(1)
+		go func(miner *Miner) {
+			defer wg.Done()
+			start := time.Now()
+			quote, err := c.FeeQuote(ctx, miner)
+
+			// Don't record the probes canceled by the caller
+			if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+				return
+			}
+			c.healthMonitor.record(miner, healthProbe{failed: err != nil, latency: time.Since(start)}, quote)
+		}(miner)
+	}
+
+	// Waiting for all probes to finish
+	wg.Wait()
+}
+
+// record will record the probe of a miner
+func (m *healthMonitor) record(miner *Miner, probe healthProbe, quote *FeeQuoteResponse) {
+	m.Lock()
+	defer m.Unlock()
+
+	health, ok := m.miners[miner.MinerID]
+	if !ok {
+		health = &minerHealth{}
+		m.miners[miner.MinerID] = health
+	}
+	health.miner = miner
+	health.lastChecked = time.Now()
+	health.probes = append(health.probes, probe)
+	if window := m.options.Window; window > 0 && len(health.probes) > window {
+		health.probes = health.probes[len(health.probes)-window:]
+	}
+	if quote != nil {
+		health.quote = quote
+		if quote.Quote != nil {
+			health.blockHeight = quote.Quote.CurrentHighestBlockHeight
+		}
+	}
+}
+
+// forget will remove the health of the miner
+func (m *healthMonitor) forget(minerID string) {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.miners, minerID)
+}
+
+// prune will remove the health of the miners that are no longer in the client
+func (m *healthMonitor) prune(miners []*Miner) {
+	m.Lock()
+	defer m.Unlock()
+
+	known := make(map[string]bool, len(miners))
+	for _, miner := range miners {
+		known[miner.MinerID] = true
+	}
+	for minerID := range m.miners {
+		if !known[minerID] {
+			delete(m.miners, minerID)
+		}
+	}
+}
+
+// running will return true if the health monitor is running
+func (m *healthMonitor) running() bool {
+	m.RLock()
+	defer m.RUnlock()
+	return m.cancel != nil
+}
+
+// scores will return the scores of the miners with recorded probes
+func (m *healthMonitor) scores() []*MinerScore {
+	m.RLock()
+	defer m.RUnlock()
+
+	var highest uint64
+	for _, health := range m.miners {
+		if health.blockHeight > highest {
+			highest = health.blockHeight
+		}
+	}
+
+	scores := make([]*MinerScore, 0, len(m.miners))
+	for _, health := range m.miners {
+		scores = append(scores, health.score(highest))
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score == scores[j].Score {
+			return scores[i].Miner.Name < scores[j].Miner.Name
+		}
+		return scores[i].Score > scores[j].Score
+	})
+	return scores
+}
+
+// score will compute the score of the miner given the highest block height of the miners
+func (h *minerHealth) score(highestBlockHeight uint64) *MinerScore {
+	score := &MinerScore{
+		BlockHeight: h.blockHeight,
+		LastChecked: h.lastChecked,
+		Miner:       h.miner,
+		Probes:      len(h.probes),
+	}
+	if h.quote != nil {
+		score.Quote = copyFeeQuote(h.quote)
+	}
+	if len(h.probes) == 0 {
+		return score
+	}
+
+	var failures int
+	var latency time.Duration
+	for _, probe := range h.probes {
+		if probe.failed {
+			failures++
+			continue
+		}
+		latency += probe.latency
+	}
+	if successes := len(h.probes) - failures; successes > 0 {
+		score.Latency = latency / time.Duration(successes)
+	}
+	score.ErrorRate = float64(failures) / float64(len(h.probes))
+	score.Healthy = !h.probes[len(h.probes)-1].failed
+
+	// Miners without a block height (Arc) are not lagging
+	if h.blockHeight > 0 {
+		score.BlockLag = highestBlockHeight - h.blockHeight
+	}
+
+	score.Score = (1 - score.ErrorRate) /
+		(1 + score.Latency.Seconds()) /
+		(1 + float64(score.BlockLag))
+	return score
+}
+
+// MinerScore will return the health recorded for the miner, nil if it was never probed
+func (c *Client) MinerScore(miner *Miner) *MinerScore {
+	if miner == nil {
+		return nil
+	}
+	for _, score := range c.healthMonitor.scores() {
+		if score.Miner.MinerID == miner.MinerID {
+			return score
+		}
+	}
+	return nil
+}
+
+// MinerScores will return the health recorded for the miners (highest score first)
+func (c *Client) MinerScores() []*MinerScore {
+	return c.healthMonitor.scores()
+}
+
+// monitoredQuotes will return the unexpired quotes of the healthy miners (highest score first)
+// if the health monitor is running
+func (c *Client) monitoredQuotes() []*FeeQuoteResponse {
+	if !c.healthMonitor.running() {
+		return nil
+	}
+
+	now := time.Now()
+	var quotes []*FeeQuoteResponse
+	for _, score := range c.healthMonitor.scores() {
+		if score.Healthy && score.Quote != nil && !quoteExpired(score.Quote, now) &&
+			c.CircuitState(score.Miner, "") != CircuitOpen {
+			quotes = append(quotes, score.Quote)
+		}
+	}
+	return quotes
+}
+
+// copyFeeQuote will return a copy of the quote (the recorded quotes are shared by the callers)
+func copyFeeQuote(quote *FeeQuoteResponse) *FeeQuoteResponse {
+	quoteCopy := *quote
+	if quote.Miner != nil {
+		miner := *quote.Miner
+		quoteCopy.Miner = &miner
+	}
+	if quote.Signature != nil {
+		signature := *quote.Signature
+		quoteCopy.Signature = &signature
+	}
+	if quote.PublicKey != nil {
+		publicKey := *quote.PublicKey
+		quoteCopy.PublicKey = &publicKey
+	}
+	if quote.Quote != nil {
+		payload := *quote.Quote
+		payload.Fees = make([]*bt.Fee, len(quote.Quote.Fees))
+		for i, fee := range quote.Quote.Fees {
+			if fee != nil {
+				feeCopy := *fee
+				payload.Fees[i] = &feeCopy
+			}
+		}
+		quoteCopy.Quote = &payload
+	}
+	return &quoteCopy
+}
+
+// quoteExpired will return true if the quote has an expiry time before now (Arc quotes don't expire)
+func quoteExpired(quote *FeeQuoteResponse, now time.Time) bool {
+	if quote.Quote == nil || len(quote.Quote.ExpirationTime) == 0 {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339Nano, quote.Quote.ExpirationTime)
+	return err == nil && expiry.Before(now)
+}
+
+// healthScoreSelector selects the miner with the highest health score
+type healthScoreSelector struct{}
+
+// NewHealthScoreSelector will return a MinerSelector that selects the miner with the highest
+// health score (see StartHealthMonitor), or the first miner if none was probed
+func NewHealthScoreSelector() MinerSelector {
+	return &healthScoreSelector{}
+}
+
+// SelectMiner will select the miner with the highest score
+func (s *healthScoreSelector) SelectMiner(_ context.Context, client ClientInterface, miners []*Miner) (*Miner, error) {
+	if len(miners) == 0 {
+		return nil, ErrNoMinerSelected
+	}
+
+	best := miners[0]
+	var bestScore float64
+	for _, miner := range miners {
+		if score := client.MinerScore(miner); score != nil && score.Score > bestScore {
+			best = miner
+			bestScore = score.Score
+		}
+	}
+	return best, nil
+}
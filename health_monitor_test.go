@@ -0,0 +1,188 @@
+package minercraft
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tonicpow/go-minercraft/v2/apis/mapi"
+)
+
+// mapiFeeQuoteBody returns a mAPI fee quote response with the given block height and mining fee
+func mapiFeeQuoteBody(blockHeight uint64, satoshis int) string {
+	fee := func(feeType string) string {
+		return fmt.Sprintf(`{\"feeType\":\"%s\",\"miningFee\":{\"satoshis\":%d,\"bytes\":1000},`+
+			`\"relayFee\":{\"satoshis\":250,\"bytes\":1000}}`, feeType, satoshis)
+	}
+	return fmt.Sprintf(`{"payload":"{\"currentHighestBlockHeight\":%d,\"fees\":[%s,%s]}"}`,
+		blockHeight, fee(mapi.FeeTypeStandard), fee(mapi.FeeTypeData))
+}
+
+// newTestMonitorClient returns a client where Taal is lagging with lower fees and GorillaPool is up-to-date
+func newTestMonitorClient(t *testing.T, taal mockResponse) *Client {
+	options := DefaultClientOptions()
+	options.RequestRetryCount = 0
+	client, err := createClient(options, MAPI, &mockHTTPByHost{responses: map[string]mockResponse{
+		"taal":        taal,
+		"gorillapool": {statusCode: http.StatusOK, body: mapiFeeQuoteBody(1000, 500)},
+	}}, nil, nil)
+	require.NoError(t, err)
+	return client
+}
+
+// TestClient_ProbeMiners tests the method ProbeMiners()
+func TestClient_ProbeMiners(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not probed", func(t *testing.T) {
+		client := newTestMonitorClient(t, mockResponse{statusCode: http.StatusOK, body: mapiFeeQuoteBody(998, 100)})
+		assert.Nil(t, client.MinerScore(client.MinerByName(MinerTaal)))
+		assert.Empty(t, client.MinerScores())
+	})
+
+	t.Run("block lag lowers the score", func(t *testing.T) {
+		client := newTestMonitorClient(t, mockResponse{statusCode: http.StatusOK, body: mapiFeeQuoteBody(998, 100)})
+		client.ProbeMiners(context.Background())
+
+		taal := client.MinerScore(client.MinerByName(MinerTaal))
+		require.NotNil(t, taal)
+		assert.True(t, taal.Healthy)
+		assert.Equal(t, uint64(998), taal.BlockHeight)
+		assert.Equal(t, uint64(2), taal.BlockLag)
+		assert.Equal(t, 1, taal.Probes)
+		assert.NotNil(t, taal.Quote)
+
+		gorilla := client.MinerScore(client.MinerByName(MinerGorillaPool))
+		require.NotNil(t, gorilla)
+		assert.Equal(t, uint64(0), gorilla.BlockLag)
+		assert.Greater(t, gorilla.Score, taal.Score)
+		assert.Equal(t, MinerGorillaPool, client.MinerScores()[0].Miner.Name)
+	})
+
+	t.Run("errors lower the score", func(t *testing.T) {
+		client := newTestMonitorClient(t, mockResponse{statusCode: http.StatusInternalServerError})
+		client.ProbeMiners(context.Background())
+		client.ProbeMiners(context.Background())
+
+		taal := client.MinerScore(client.MinerByName(MinerTaal))
+		require.NotNil(t, taal)
+		assert.False(t, taal.Healthy)
+		assert.Equal(t, float64(1), taal.ErrorRate)
+		assert.Equal(t, float64(0), taal.Score)
+		assert.Equal(t, 2, taal.Probes)
+
+		miner, err := NewHealthScoreSelector().SelectMiner(context.Background(), client, client.Miners())
+		require.NoError(t, err)
+		assert.Equal(t, MinerGorillaPool, miner.Name)
+	})
+
+	t.Run("removed miners are pruned", func(t *testing.T) {
+		client := newTestMonitorClient(t, mockResponse{statusCode: http.StatusOK, body: mapiFeeQuoteBody(998, 100)})
+		client.ProbeMiners(context.Background())
+		require.Len(t, client.MinerScores(), 2)
+
+		taal := client.MinerByName(MinerTaal)
+		client.RemoveMiner(taal)
+		client.ProbeMiners(context.Background())
+		require.Len(t, client.MinerScores(), 1)
+		assert.Nil(t, client.MinerScore(taal))
+	})
+
+	t.Run("scores are kept by miner id", func(t *testing.T) {
+		client := newTestMonitorClient(t, mockResponse{statusCode: http.StatusOK, body: mapiFeeQuoteBody(998, 100)})
+		client.ProbeMiners(context.Background())
+
+		taal := client.MinerByName(MinerTaal)
+		require.NoError(t, client.UpdateMiner(taal.MinerID, Miner{Name: "Renamed"}))
+		client.ProbeMiners(context.Background())
+		require.Len(t, client.MinerScores(), 2)
+
+		score := client.MinerScore(client.MinerByName("Renamed"))
+		require.NotNil(t, score)
+		assert.Equal(t, 2, score.Probes)
+		assert.Equal(t, "Renamed", score.Miner.Name)
+	})
+}
+
+// TestClient_HealthMonitor tests the methods StartHealthMonitor() and StopHealthMonitor()
+func TestClient_HealthMonitor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("probes in the background", func(t *testing.T) {
+		client := newTestMonitorClient(t, mockResponse{statusCode: http.StatusOK, body: mapiFeeQuoteBody(998, 100)})
+		require.NoError(t, client.StartHealthMonitor(context.Background(), &HealthMonitorOptions{Interval: 10 * time.Millisecond}))
+		require.ErrorIs(t, client.StartHealthMonitor(context.Background(), nil), ErrHealthMonitorRunning)
+
+		require.Eventually(t, func() bool {
+			score := client.MinerScore(client.MinerByName(MinerTaal))
+			return score != nil && score.Probes >= 2
+		}, time.Second, 5*time.Millisecond)
+
+		client.StopHealthMonitor()
+		probes := client.MinerScore(client.MinerByName(MinerTaal)).Probes
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(t, probes, client.MinerScore(client.MinerByName(MinerTaal)).Probes)
+
+		// Can be restarted
+		require.NoError(t, client.StartHealthMonitor(context.Background(), nil))
+		client.StopHealthMonitor()
+	})
+
+	t.Run("quotes use the monitored quotes", func(t *testing.T) {
+		httpClient := &mockHTTPByHost{responses: map[string]mockResponse{
+			"taal":        {statusCode: http.StatusOK, body: mapiFeeQuoteBody(998, 100)},
+			"gorillapool": {statusCode: http.StatusOK, body: mapiFeeQuoteBody(1000, 500)},
+		}}
+		client, err := createClient(DefaultClientOptions(), MAPI, httpClient, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, client.StartHealthMonitor(context.Background(), &HealthMonitorOptions{Interval: time.Hour}))
+		defer client.StopHealthMonitor()
+		require.Eventually(t, func() bool { return len(client.MinerScores()) == 2 }, time.Second, 5*time.Millisecond)
+
+		// Miners are down, the monitored quotes are used
+		httpClient.responses = map[string]mockResponse{
+			"taal":        {statusCode: http.StatusBadRequest, body: `{}`},
+			"gorillapool": {statusCode: http.StatusBadRequest, body: `{}`},
+		}
+
+		best, err := client.BestQuote(context.Background(), mapi.FeeCategoryMining, mapi.FeeTypeData)
+		require.NoError(t, err)
+		assert.Equal(t, MinerTaal, best.Miner.Name)
+
+		fastest, err := client.FastestQuote(context.Background(), time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, MinerGorillaPool, fastest.Miner.Name)
+
+		// The returned quotes are copies
+		fastest.Miner.Name = "changed"
+		fastest.Quote.Fees[0].MiningFee.Satoshis = 1
+		again, err := client.FastestQuote(context.Background(), time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, MinerGorillaPool, again.Miner.Name)
+		assert.Equal(t, 500, again.Quote.Fees[0].MiningFee.Satoshis)
+	})
+
+	t.Run("expired quotes are not used", func(t *testing.T) {
+		expired := strings.Replace(mapiFeeQuoteBody(998, 100), `{\"currentHighestBlockHeight`,
+			`{\"expiryTime\":\"`+time.Now().Add(-time.Minute).UTC().Format(time.RFC3339Nano)+
+				`\",\"currentHighestBlockHeight`, 1)
+		httpClient := &mockHTTPByHost{responses: map[string]mockResponse{
+			"taal":        {statusCode: http.StatusOK, body: expired},
+			"gorillapool": {statusCode: http.StatusOK, body: mapiFeeQuoteBody(1000, 500)},
+		}}
+		client, err := createClient(DefaultClientOptions(), MAPI, httpClient, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, client.StartHealthMonitor(context.Background(), &HealthMonitorOptions{Interval: time.Hour}))
+		defer client.StopHealthMonitor()
+		require.Eventually(t, func() bool { return len(client.MinerScores()) == 2 }, time.Second, 5*time.Millisecond)
+
+		quotes := client.monitoredQuotes()
+		require.Len(t, quotes, 1)
+		assert.Equal(t, uint64(1000), quotes[0].Quote.CurrentHighestBlockHeight)
+	})
+}
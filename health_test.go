@@ -0,0 +1,127 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHTTPArcHealth for mocking requests
+type mockHTTPArcHealth struct {
+	body string
+}
+
+// Do is a mock http request
+func (m *mockHTTPArcHealth) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusBadRequest
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	if strings.HasSuffix(req.URL.String(), "/v1/health") {
+		resp.StatusCode = http.StatusOK
+		resp.Body = io.NopCloser(bytes.NewBufferString(m.body))
+		return resp, nil
+	}
+
+	return resp, fmt.Errorf("unexpected request: %s", req.URL.String())
+}
+
+// TestClient_Health tests the method Health()
+func TestClient_Health(t *testing.T) {
+	t.Parallel()
+
+	t.Run("healthy arc", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPArcHealth{
+			body: `{"healthy": true, "reason": "no error", "version": "v1.1.53"}`,
+		})
+
+		response, err := client.Health(context.Background(), client.MinerByName(MinerTaal))
+		require.NoError(t, err)
+		require.NotNil(t, response)
+
+		assert.True(t, response.Healthy)
+		assert.Equal(t, Arc, response.APIType)
+		assert.Equal(t, "v1.1.53", response.Version)
+		assert.Equal(t, MinerTaal, response.Miner.Name)
+	})
+
+	t.Run("unhealthy arc", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPArcHealth{
+			body: `{"healthy": false, "reason": "metamorph is not reachable"}`,
+		})
+
+		response, err := client.Health(context.Background(), client.MinerByName(MinerTaal))
+		require.NoError(t, err)
+		assert.False(t, response.Healthy)
+		assert.Equal(t, "metamorph is not reachable", response.Reason)
+	})
+
+	t.Run("invalid arc response", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPArcHealth{body: "invalid"})
+
+		response, err := client.Health(context.Background(), client.MinerByName(MinerTaal))
+		require.NoError(t, err)
+		assert.False(t, response.Healthy)
+		assert.NotEmpty(t, response.Reason)
+	})
+
+	t.Run("healthy mapi", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+
+		response, err := client.Health(context.Background(), client.MinerByName(MinerTaal))
+		require.NoError(t, err)
+		assert.True(t, response.Healthy)
+		assert.Equal(t, MAPI, response.APIType)
+	})
+
+	t.Run("mapi without fees", func(t *testing.T) {
+		client := newTestClient(&mockHTTPMissingFees{})
+
+		response, err := client.Health(context.Background(), client.MinerByName(MinerTaal))
+		require.NoError(t, err)
+		assert.False(t, response.Healthy)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		client := newTestClient(&mockHTTPError{})
+
+		response, err := client.Health(context.Background(), client.MinerByName(MinerTaal))
+		require.NoError(t, err)
+		assert.False(t, response.Healthy)
+		assert.NotEmpty(t, response.Reason)
+	})
+
+	t.Run("invalid miner", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+
+		response, err := client.Health(context.Background(), nil)
+		require.Error(t, err)
+		assert.Nil(t, response)
+	})
+}
+
+// ExampleClient_Health example using Health()
+func ExampleClient_Health() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestArcClient(&mockHTTPArcHealth{body: `{"healthy": true}`})
+
+	response, err := client.Health(context.Background(), client.MinerByName(MinerTaal))
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("%s is healthy: %t", response.Miner.Name, response.Healthy)
+	// Output:Taal is healthy: true
+}
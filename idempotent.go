@@ -0,0 +1,131 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/libsv/go-bt/v2"
+	"github.com/tonicpow/go-minercraft/v2/apis/mapi"
+)
+
+// WithIdempotentSubmit will make SubmitTransaction and SubmitTransactions treat an already
+// known (or already mined) transaction as a successful submission.
+//
+// The result is marked with AlreadyKnown and the current status of the transaction is added
+// using a QueryTransaction (if the query fails the submission is still successful).
+// This makes it safe to resubmit a transaction after a timeout.
+func WithIdempotentSubmit() SubmitTransactionOptFunc {
+	return func(o *submitTransactionOpts) {
+		o.idempotent = true
+	}
+}
+
+// isAlreadyKnownError will return true if the error response means the transaction is already known
+func isAlreadyKnownError(err error) bool {
+	var arcErr ArcErrorResponse
+	if errors.As(err, &arcErr) {
+		return mapi.ClassifyRejection(
+			strings.Join([]string{arcErr.Title, arcErr.Detail, arcErr.ExtraInfo}, " "),
+		) == mapi.RejectionAlreadyKnown
+	}
+
+	var mapiErr ErrorResponse
+	if errors.As(err, &mapiErr) {
+		return mapi.ClassifyRejection(mapiErr.Title+" "+mapiErr.Detail) == mapi.RejectionAlreadyKnown
+	}
+	return false
+}
+
+// transactionID will return the txid of the transaction (empty if the raw tx is invalid)
+func transactionID(tx *Transaction) string {
+	switch {
+	case tx.BEEF != nil:
+		if subject := tx.BEEF.SubjectTx(); subject != nil {
+			return subject.TxID()
+		}
+	case tx.Tx != nil:
+		return tx.Tx.TxID()
+	case len(tx.RawTx) > 0:
+		if btTx, err := bt.NewTxFromString(tx.RawTx); err == nil {
+			return btTx.TxID()
+		}
+	}
+	return ""
+}
+
+// alreadyKnownStatus will mark the result as a success and add the current status of the transaction
+func (c *Client) alreadyKnownStatus(ctx context.Context, miner *Miner, apiType APIType,
+	results *UnifiedSubmissionPayload) {
+
+	results.AlreadyKnown = true
+	results.RejectionKind = ""
+	if apiType == MAPI {
+		results.ReturnResult = QueryTransactionSuccess
+	}
+
+	if len(results.TxID) == 0 {
+		return
+	}
+
+	response, err := c.QueryTransaction(ctx, miner, results.TxID, WithQueryAPIType(apiType))
+	if err != nil || response.Query == nil {
+		return
+	}
+
+	results.BlockHash = response.Query.BlockHash
+	results.BlockHeight = response.Query.BlockHeight
+	if len(response.Query.TxStatus) > 0 {
+		results.TxStatus = response.Query.TxStatus
+	}
+}
+
+// alreadyKnownSubmitResponse will create a successful submit response for an already known transaction
+func (c *Client) alreadyKnownSubmitResponse(ctx context.Context, result *internalResult,
+	tx *Transaction) *SubmitTransactionResponse {
+
+	var arcErr ArcErrorResponse
+	txID := transactionID(tx)
+	if errors.As(result.Response.Error, &arcErr) && len(arcErr.TxID) > 0 {
+		txID = arcErr.TxID
+	}
+
+	response := &SubmitTransactionResponse{
+		JSONEnvelope: JSONEnvelope{
+			APIType: result.APIType,
+			Miner:   result.Miner,
+		},
+		Results: &UnifiedSubmissionPayload{TxID: txID},
+	}
+	c.alreadyKnownStatus(ctx, result.Miner, result.APIType, response.Results)
+	return response
+}
+
+// alreadyKnownTxs will mark the already known transactions of a batch as successful
+func (c *Client) alreadyKnownTxs(ctx context.Context, miner *Miner, apiType APIType,
+	response *SubmitTransactionsResponse) {
+	for i := range response.Payload.Txs {
+		tx := &response.Payload.Txs[i]
+		if tx.RejectionKind != mapi.RejectionAlreadyKnown {
+			continue
+		}
+
+		results := &UnifiedSubmissionPayload{TxID: tx.TxID, TxStatus: tx.TxStatus}
+		c.alreadyKnownStatus(ctx, miner, apiType, results)
+
+		tx.AlreadyKnown = true
+		tx.BlockHash = results.BlockHash
+		tx.BlockHeight = results.BlockHeight
+		tx.RejectionKind = results.RejectionKind
+		if apiType == MAPI {
+			tx.ReturnResult = results.ReturnResult
+			if response.Payload.FailureCount > 0 {
+				response.Payload.FailureCount--
+			}
+			continue
+		}
+		tx.Status = http.StatusOK
+		tx.TxStatus = results.TxStatus
+	}
+}
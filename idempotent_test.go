@@ -0,0 +1,196 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/libsv/go-bt/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tonicpow/go-minercraft/v2/apis/arc"
+	"github.com/tonicpow/go-minercraft/v2/apis/mapi"
+)
+
+// newMockHTTPAlreadyKnown will return a mock client answering the submit and query requests
+func newMockHTTPAlreadyKnown(submitStatus int, submitBody, queryBody string) *MockClient {
+	return &MockClient{MockDo: func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBufferString(""))}
+		switch {
+		case req.Method == http.MethodPost:
+			resp.StatusCode = submitStatus
+			resp.Body = io.NopCloser(bytes.NewBufferString(submitBody))
+		case len(queryBody) > 0 && strings.Contains(req.URL.Path, "/tx/"):
+			resp.StatusCode = http.StatusOK
+			resp.Body = io.NopCloser(bytes.NewBufferString(queryBody))
+		}
+		return resp, nil
+	}}
+}
+
+// TestClient_SubmitTransaction_Idempotent tests the method SubmitTransaction() with WithIdempotentSubmit()
+func TestClient_SubmitTransaction_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	btTx, err := bt.NewTxFromString(submitTestExampleTx)
+	require.NoError(t, err)
+	txID := btTx.TxID()
+	tx := &Transaction{RawTx: submitTestExampleTx}
+
+	mapiSubmit := `{"payload": "{\"txid\":\"` + txID +
+		`\",\"returnResult\":\"failure\",\"resultDescription\":\"257: txn-already-known\"}"}`
+	mapiQuery := `{"payload": "{\"txid\":\"` + txID +
+		`\",\"returnResult\":\"success\",\"blockHash\":\"0000000000000000064cbaac5cedf71a5447771573ba585501952c023873817b\",\"blockHeight\":807650,\"confirmations\":2}"}`
+
+	t.Run("mapi already known", func(t *testing.T) {
+		client := newTestClient(newMockHTTPAlreadyKnown(http.StatusOK, mapiSubmit, mapiQuery))
+
+		response, err := client.SubmitTransaction(
+			context.Background(), client.MinerByName(MinerTaal), tx, WithIdempotentSubmit(),
+		)
+		require.NoError(t, err)
+
+		assert.True(t, response.Results.AlreadyKnown)
+		assert.Equal(t, QueryTransactionSuccess, response.Results.ReturnResult)
+		assert.Empty(t, response.Results.RejectionKind)
+		assert.Equal(t, int64(807650), response.Results.BlockHeight)
+	})
+
+	t.Run("mapi already known, query failed", func(t *testing.T) {
+		client := newTestClient(newMockHTTPAlreadyKnown(http.StatusOK, mapiSubmit, ""))
+
+		response, err := client.SubmitTransaction(
+			context.Background(), client.MinerByName(MinerTaal), tx, WithIdempotentSubmit(),
+		)
+		require.NoError(t, err)
+		assert.True(t, response.Results.AlreadyKnown)
+		assert.Equal(t, QueryTransactionSuccess, response.Results.ReturnResult)
+		assert.Empty(t, response.Results.BlockHash)
+	})
+
+	t.Run("mapi already known, not idempotent", func(t *testing.T) {
+		client := newTestClient(newMockHTTPAlreadyKnown(http.StatusOK, mapiSubmit, mapiQuery))
+
+		response, err := client.SubmitTransaction(context.Background(), client.MinerByName(MinerTaal), tx)
+		require.NoError(t, err)
+		assert.False(t, response.Results.AlreadyKnown)
+		assert.Equal(t, QueryTransactionFailure, response.Results.ReturnResult)
+	})
+
+	arcSubmit := `{"status": 409, "title": "Generic error", "detail": "Transaction already in the mempool"}`
+	arcQuery := `{"txid": "` + txID + `", "txStatus": "MINED", "blockHeight": 807650}`
+
+	t.Run("arc already known", func(t *testing.T) {
+		client := newTestArcClient(newMockHTTPAlreadyKnown(http.StatusConflict, arcSubmit, arcQuery))
+
+		response, err := client.SubmitTransaction(
+			context.Background(), client.MinerByName(MinerTaal), tx, WithIdempotentSubmit(),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, Arc, response.APIType)
+		assert.True(t, response.Results.AlreadyKnown)
+		assert.Equal(t, txID, response.Results.TxID)
+		assert.Equal(t, arc.Mined, response.Results.TxStatus)
+		assert.Equal(t, int64(807650), response.Results.BlockHeight)
+	})
+
+	t.Run("arc already known, not idempotent", func(t *testing.T) {
+		client := newTestArcClient(newMockHTTPAlreadyKnown(http.StatusConflict, arcSubmit, arcQuery))
+
+		_, err := client.SubmitTransaction(context.Background(), client.MinerByName(MinerTaal), tx)
+		require.ErrorIs(t, err, ErrArcGeneric)
+	})
+
+	t.Run("arc other error", func(t *testing.T) {
+		client := newTestArcClient(newMockHTTPAlreadyKnown(
+			465, `{"status": 465, "title": "Fee too low"}`, arcQuery,
+		))
+
+		_, err := client.SubmitTransaction(
+			context.Background(), client.MinerByName(MinerTaal), tx, WithIdempotentSubmit(),
+		)
+		require.ErrorIs(t, err, ErrArcFeeTooLow)
+	})
+}
+
+// TestClient_SubmitTransactions_Idempotent tests the method SubmitTransactions() with WithIdempotentSubmit()
+func TestClient_SubmitTransactions_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	submit := `{"payload": "{\"failureCount\":2,\"txs\":[` +
+		`{\"txid\":\"` + testTx + `\",\"returnResult\":\"failure\",\"resultDescription\":\"Transaction already known\"},` +
+		`{\"txid\":\"c8a087b1ee775fa29697511ecd64e800941c8a22db6ed0989fb27a1d2d6798da\",\"returnResult\":\"failure\",\"resultDescription\":\"Missing inputs\"}]}"}`
+	txs := []Transaction{{RawTx: rawTx}, {RawTx: submitTestExampleTx}}
+
+	t.Run("idempotent", func(t *testing.T) {
+		client := newTestClient(newMockHTTPAlreadyKnown(http.StatusOK, submit, ""))
+
+		response, err := client.SubmitTransactions(
+			context.Background(), client.MinerByName(MinerTaal), txs, WithIdempotentSubmit(),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, response.Payload.FailureCount)
+		assert.True(t, response.Payload.Txs[0].AlreadyKnown)
+		assert.Equal(t, QueryTransactionSuccess, response.Payload.Txs[0].ReturnResult)
+		assert.Empty(t, response.Payload.Txs[0].RejectionKind)
+		assert.False(t, response.Payload.Txs[1].AlreadyKnown)
+		assert.Equal(t, mapi.RejectionMissingInputs, response.Payload.Txs[1].RejectionKind)
+		assert.Equal(t, QueryTransactionFailure, response.Payload.Txs[1].ReturnResult)
+	})
+
+	t.Run("not idempotent", func(t *testing.T) {
+		client := newTestClient(newMockHTTPAlreadyKnown(http.StatusOK, submit, ""))
+
+		response, err := client.SubmitTransactions(context.Background(), client.MinerByName(MinerTaal), txs)
+		require.NoError(t, err)
+		assert.Equal(t, 2, response.Payload.FailureCount)
+		assert.False(t, response.Payload.Txs[0].AlreadyKnown)
+	})
+}
+
+// TestClient_SubmitTransactions_IdempotentArc tests the method SubmitTransactions() with WithIdempotentSubmit() on Arc
+func TestClient_SubmitTransactions_IdempotentArc(t *testing.T) {
+	t.Parallel()
+
+	submit := `[` +
+		`{"txid":"` + testTx + `","status":409,"title":"Generic error","extraInfo":"Transaction already in the mempool"},` +
+		`{"txid":"c8a087b1ee775fa29697511ecd64e800941c8a22db6ed0989fb27a1d2d6798da","status":465,"title":"Fee too low"}]`
+	query := `{"txid": "` + testTx + `", "txStatus": "MINED", "blockHeight": 807650}`
+	txs := []Transaction{{RawTx: rawTx}, {RawTx: submitTestExampleTx}}
+
+	t.Run("idempotent", func(t *testing.T) {
+		client := newTestArcClient(newMockHTTPAlreadyKnown(http.StatusOK, submit, query))
+
+		response, err := client.SubmitTransactions(
+			context.Background(), client.MinerByName(MinerTaal), txs, WithIdempotentSubmit(),
+		)
+		require.NoError(t, err)
+
+		known := response.Payload.Txs[0]
+		assert.True(t, known.AlreadyKnown)
+		assert.Empty(t, known.RejectionKind)
+		assert.Equal(t, http.StatusOK, known.Status)
+		assert.Equal(t, arc.Mined, known.TxStatus)
+		assert.Equal(t, int64(807650), known.BlockHeight)
+
+		failed := response.Payload.Txs[1]
+		assert.False(t, failed.AlreadyKnown)
+		assert.Equal(t, mapi.RejectionFeeTooLow, failed.RejectionKind)
+		assert.Equal(t, 465, failed.Status)
+	})
+
+	t.Run("not idempotent", func(t *testing.T) {
+		client := newTestArcClient(newMockHTTPAlreadyKnown(http.StatusOK, submit, query))
+
+		response, err := client.SubmitTransactions(context.Background(), client.MinerByName(MinerTaal), txs)
+		require.NoError(t, err)
+		assert.False(t, response.Payload.Txs[0].AlreadyKnown)
+		assert.Equal(t, mapi.RejectionAlreadyKnown, response.Payload.Txs[0].RejectionKind)
+		assert.Equal(t, http.StatusConflict, response.Payload.Txs[0].Status)
+	})
+}
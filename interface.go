@@ -16,6 +16,7 @@ type QuoteService interface {
 // MinerService is the MinerCraft miner related methods
 type MinerService interface {
 	AddMiner(miner Miner, apis []API) error
+	AddMinerAPI(minerID string, api API) error
 	MinerByID(minerID string) *Miner
 	MinerByName(name string) *Miner
 	Miners() []*Miner
@@ -23,13 +24,20 @@ type MinerService interface {
 	MinerAPIByMinerID(minerID string, apiType APIType) (*API, error)
 	MinerUpdateToken(name, token string, apiType APIType)
 	RemoveMiner(miner *Miner) bool
+	RemoveMinerAPI(minerID string, apiType APIType) error
+	SetMinerAPIPreference(minerID string, apiTypes ...APIType) error
+	SetMinerAPIs(minerID string, apis []API) error
+	UpdateMiner(minerID string, update Miner) error
+	UpdateMinerToken(name, token string, apiType APIType) error
 }
 
 // TransactionService is the MinerCraft transaction related methods
 type TransactionService interface {
+	Broadcast(ctx context.Context, tx *Transaction, opts *BroadcastOptions) (*BroadcastResponse, error)
 	QueryTransaction(ctx context.Context, miner *Miner, txID string, opts ...QueryTransactionOptFunc) (*QueryTransactionResponse, error)
-	SubmitTransaction(ctx context.Context, miner *Miner, tx *Transaction) (*SubmitTransactionResponse, error)
-	SubmitTransactions(ctx context.Context, miner *Miner, txs []Transaction) (*SubmitTransactionsResponse, error)
+	SubmitTransaction(ctx context.Context, miner *Miner, tx *Transaction, opts ...SubmitTransactionOptFunc) (*SubmitTransactionResponse, error)
+	SubmitTransactions(ctx context.Context, miner *Miner, txs []Transaction, opts ...SubmitTransactionOptFunc) (*SubmitTransactionsResponse, error)
+	SubmitWithFailover(ctx context.Context, tx *Transaction, miners []*Miner, opts ...SubmitTransactionOptFunc) (*FailoverResponse, error)
 }
 
 // ClientInterface is the MinerCraft client interface
@@ -39,4 +47,12 @@ type ClientInterface interface {
 	TransactionService
 	UserAgent() string
 	APIType() APIType
+	Health(ctx context.Context, miner *Miner) (*HealthResponse, error)
+	CircuitState(miner *Miner, apiType APIType) CircuitState
+	CircuitStates() map[string]CircuitState
+	MinerScore(miner *Miner) *MinerScore
+	MinerScores() []*MinerScore
+	ProbeMiners(ctx context.Context)
+	StartHealthMonitor(ctx context.Context, opts *HealthMonitorOptions) error
+	StopHealthMonitor()
 }
@@ -0,0 +1,181 @@
+package minercraft
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/libsv/go-bc"
+	"github.com/tonicpow/go-minercraft/v2/apis/mapi"
+)
+
+// ErrInvalidCallbackSignature is returned when the mAPI callback envelope is not signed by a known miner
+var ErrInvalidCallbackSignature = errors.New("invalid callback signature")
+
+// ErrMissingCallbackAuth is returned when a mAPI callback handler has no token and no trusted miner IDs
+var ErrMissingCallbackAuth = errors.New("a callback token or trusted miner IDs are required")
+
+// MapiCallbackEvent is a callback received from mAPI with the callbackPayload decoded
+//
+// Depending on the CallbackReason, MerkleProof (merkleProof) or DoubleSpend
+// (doubleSpend & doubleSpendAttempt) is set.
+type MapiCallbackEvent struct {
+	mapi.Callback
+	DoubleSpend *mapi.DoubleSpendCallbackPayload `json:"doubleSpend,omitempty"`
+	MerkleProof *bc.MerkleProof                  `json:"merkleProof,omitempty"`
+	PublicKey   string                           `json:"publicKey"` // Public key that signed the callback
+}
+
+// MapiCallbackFunc is called for every event received by the MapiCallbackHandler
+//
+// If an error is returned the callback is answered with a 500, so mAPI will send it again.
+type MapiCallbackFunc func(ctx context.Context, event *MapiCallbackEvent) error
+
+// MapiCallbackHandler is an http.Handler receiving the mAPI callbacks (merkle proofs and double spends)
+//
+// Set the CallBackURL of the Transaction to the url of this handler and the CallBackToken
+// to the token of this handler. The callback envelope must be signed by one of the miner IDs.
+type MapiCallbackHandler struct {
+	minerIDs map[string]bool
+	onEvent  MapiCallbackFunc
+	token    string
+}
+
+// NewMapiCallbackHandler will create a new handler calling the given function for every event
+//
+// The token must match the CallBackToken used when submitting (an empty token disables the check).
+// The minerIDs are the public keys allowed to sign the callbacks (empty accepts any valid signature).
+// As anyone can sign an envelope, a token or at least one miner ID is required (ErrMissingCallbackAuth).
+func NewMapiCallbackHandler(token string, minerIDs []string, onEvent MapiCallbackFunc) (*MapiCallbackHandler, error) {
+	if len(token) == 0 && len(minerIDs) == 0 {
+		return nil, ErrMissingCallbackAuth
+	}
+	h := &MapiCallbackHandler{
+		minerIDs: make(map[string]bool, len(minerIDs)),
+		onEvent:  onEvent,
+		token:    token,
+	}
+	for _, minerID := range minerIDs {
+		h.minerIDs[minerID] = true
+	}
+	return h, nil
+}
+
+// NewMapiCallbackChannelHandler will create a new handler sending every event to the given channel
+//
+// If the channel is full the handler blocks until the event is received or the request is canceled.
+func NewMapiCallbackChannelHandler(token string, minerIDs []string,
+	events chan<- *MapiCallbackEvent) (*MapiCallbackHandler, error) {
+	return NewMapiCallbackHandler(token, minerIDs, func(ctx context.Context, event *MapiCallbackEvent) error {
+		select {
+		case events <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// ServeHTTP will authorize, verify, parse and deliver the callback
+func (h *MapiCallbackHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Fail closed if the handler was not created with a token or trusted miner IDs
+	if len(h.token) == 0 && len(h.minerIDs) == 0 {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !validMapiCallbackToken(req, h.token) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxCallbackBodySize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var event *MapiCallbackEvent
+	if event, err = ParseMapiCallback(body); err != nil {
+		if errors.Is(err, ErrInvalidCallbackSignature) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(h.minerIDs) > 0 && !h.minerIDs[event.PublicKey] {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if err = h.onEvent(req.Context(), event); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// ParseMapiCallback will validate the signature of the mAPI callback envelope and decode the payload
+//
+// The signature is required, use event.PublicKey to check who signed the callback.
+func ParseMapiCallback(body []byte) (*MapiCallbackEvent, error) {
+	callbackEnvelope := new(JSONEnvelope)
+	if err := json.Unmarshal(body, callbackEnvelope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mapi callback: %w", err)
+	}
+
+	if callbackEnvelope.Signature == nil || callbackEnvelope.PublicKey == nil {
+		return nil, fmt.Errorf("callback is not signed: %w", ErrInvalidCallbackSignature)
+	}
+	isValid, err := callbackEnvelope.IsValid()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidCallbackSignature)
+	} else if !isValid {
+		return nil, ErrInvalidCallbackSignature
+	}
+
+	event := &MapiCallbackEvent{PublicKey: *callbackEnvelope.PublicKey}
+	if err = json.Unmarshal([]byte(callbackEnvelope.Payload), &event.Callback); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mapi callback payload: %w", err)
+	}
+
+	switch event.CallbackReason {
+	case mapi.CallbackReasonMerkleProof:
+		event.MerkleProof = new(bc.MerkleProof)
+		err = json.Unmarshal([]byte(event.CallbackPayload), event.MerkleProof)
+	case mapi.CallbackReasonDoubleSpend, mapi.CallbackReasonDoubleSpendAttempt:
+		event.DoubleSpend = new(mapi.DoubleSpendCallbackPayload)
+		err = json.Unmarshal([]byte(event.CallbackPayload), event.DoubleSpend)
+	default:
+		return nil, fmt.Errorf("unknown callback reason: %s", event.CallbackReason)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s callback payload: %w", event.CallbackReason, err)
+	}
+
+	return event, nil
+}
+
+// validMapiCallbackToken will check the token of the callback request (constant time)
+//
+// mAPI sends the CallBackToken as the Authorization header, with or without the Bearer prefix.
+func validMapiCallbackToken(req *http.Request, token string) bool {
+	if len(token) == 0 {
+		return true
+	}
+	authorization := []byte(req.Header.Get("Authorization"))
+	return subtle.ConstantTimeCompare(authorization, []byte(token)) == 1 ||
+		subtle.ConstantTimeCompare(authorization, []byte("Bearer "+token)) == 1
+}
@@ -0,0 +1,265 @@
+package minercraft
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/libsv/go-bk/bec"
+	"github.com/libsv/go-bk/envelope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tonicpow/go-minercraft/v2/apis/mapi"
+)
+
+const (
+	mapiCallbackToken       = "callback-token"
+	mapiCallbackMerkleProof = `{"index":1,"txOrId":"e7b3eefab33072e62283255f193ef5d22f26bbcfc0a80688fe2cc5178a32dda6","targetType":"header","target":"00000020a552fb757cf80b7341063e108884504212da2f1e1ce2ad9ffc3c6163955a27274b53d185c6b216d9f4f8831af1249d7b4b8c8ab16096cb49dda5e5fbd59517c775ba8b60ffff7f2000000000","nodes":["30361d1b60b8ca43d5cec3efc0a0c166d777ada0543ace64c4034fa25d253909","e7aa15058daf38236965670467ade59f96cfc6ec6b7b8bb05c9a7ed6926b884d"]}`
+	mapiCallbackDoubleSpend = `{"doubleSpendTxId":"f1f8d3de162f3558b97b052064ce1d0c45805fb6a1a6e2cb2f2b3d5e2c1b0a9f","payload":"` + rawTx + `"}`
+)
+
+// newSignedMapiCallback will return a signed mAPI callback envelope and the signing public key
+func newSignedMapiCallback(t *testing.T, reason, payload string) ([]byte, string) {
+	privateKey, err := bec.NewPrivateKey(bec.S256())
+	require.NoError(t, err)
+	publicKey := hex.EncodeToString(privateKey.PubKey().SerialiseCompressed())
+
+	var callback []byte
+	callback, err = json.Marshal(mapi.Callback{
+		APIVersion:      "1.4.0",
+		BlockHash:       "2ad8af91739e9dc41ea155a9ab4b14ab88fe2a0934f14420139867babf5953c4",
+		BlockHeight:     105,
+		CallbackPayload: payload,
+		CallbackReason:  reason,
+		CallbackTxID:    "e7b3eefab33072e62283255f193ef5d22f26bbcfc0a80688fe2cc5178a32dda6",
+		MinerID:         publicKey,
+		Timestamp:       "2021-04-30T08:06:13.4129624Z",
+	})
+	require.NoError(t, err)
+
+	// The signature is made over the payload without the escape characters
+	hash := sha256.Sum256([]byte(strings.ReplaceAll(string(callback), `\`, "")))
+	var signature *bec.Signature
+	signature, err = privateKey.Sign(hash[:])
+	require.NoError(t, err)
+	signatureHex := hex.EncodeToString(signature.Serialise())
+
+	var body []byte
+	body, err = json.Marshal(envelope.JSONEnvelope{
+		Payload:   string(callback),
+		Signature: &signatureHex,
+		PublicKey: &publicKey,
+		Encoding:  "UTF-8",
+		MimeType:  "application/json",
+	})
+	require.NoError(t, err)
+	return body, publicKey
+}
+
+// newMapiCallbackRequest will return a new callback request
+func newMapiCallbackRequest(body []byte, authorization string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(string(body)))
+	if len(authorization) > 0 {
+		req.Header.Set("Authorization", authorization)
+	}
+	return req
+}
+
+// TestParseMapiCallback tests the method ParseMapiCallback()
+func TestParseMapiCallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("merkle proof", func(t *testing.T) {
+		body, publicKey := newSignedMapiCallback(t, mapi.CallbackReasonMerkleProof, mapiCallbackMerkleProof)
+		event, err := ParseMapiCallback(body)
+		require.NoError(t, err)
+
+		assert.Equal(t, publicKey, event.PublicKey)
+		assert.Equal(t, uint64(105), event.BlockHeight)
+		require.NotNil(t, event.MerkleProof)
+		assert.Equal(t, uint64(1), event.MerkleProof.Index)
+		assert.Equal(t, "header", event.MerkleProof.TargetType)
+		assert.Len(t, event.MerkleProof.Nodes, 2)
+		assert.Nil(t, event.DoubleSpend)
+	})
+
+	t.Run("double spend", func(t *testing.T) {
+		for _, reason := range []string{mapi.CallbackReasonDoubleSpend, mapi.CallbackReasonDoubleSpendAttempt} {
+			body, _ := newSignedMapiCallback(t, reason, mapiCallbackDoubleSpend)
+			event, err := ParseMapiCallback(body)
+			require.NoError(t, err)
+
+			assert.Equal(t, reason, event.CallbackReason)
+			require.NotNil(t, event.DoubleSpend)
+			assert.Equal(t, "f1f8d3de162f3558b97b052064ce1d0c45805fb6a1a6e2cb2f2b3d5e2c1b0a9f", event.DoubleSpend.DoubleSpendTxID)
+			assert.Equal(t, rawTx, event.DoubleSpend.Payload)
+			assert.Nil(t, event.MerkleProof)
+		}
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		body, _ := newSignedMapiCallback(t, mapi.CallbackReasonMerkleProof, mapiCallbackMerkleProof)
+		tampered := strings.Replace(string(body), `blockHeight\":105`, `blockHeight\":106`, 1)
+		require.NotEqual(t, string(body), tampered)
+
+		_, err := ParseMapiCallback([]byte(tampered))
+		require.ErrorIs(t, err, ErrInvalidCallbackSignature)
+	})
+
+	t.Run("not signed", func(t *testing.T) {
+		_, err := ParseMapiCallback([]byte(`{"payload":"{}"}`))
+		require.ErrorIs(t, err, ErrInvalidCallbackSignature)
+	})
+
+	t.Run("unknown reason", func(t *testing.T) {
+		body, _ := newSignedMapiCallback(t, "unknown", "{}")
+		_, err := ParseMapiCallback(body)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid callback payload", func(t *testing.T) {
+		body, _ := newSignedMapiCallback(t, mapi.CallbackReasonMerkleProof, "invalid")
+		_, err := ParseMapiCallback(body)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := ParseMapiCallback([]byte("invalid"))
+		require.Error(t, err)
+	})
+}
+
+// TestMapiCallbackHandler tests the MapiCallbackHandler
+func TestMapiCallbackHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid callback", func(t *testing.T) {
+		body, publicKey := newSignedMapiCallback(t, mapi.CallbackReasonMerkleProof, mapiCallbackMerkleProof)
+
+		events := make(chan *MapiCallbackEvent, 1)
+		handler, err := NewMapiCallbackChannelHandler(mapiCallbackToken, []string{publicKey}, events)
+		require.NoError(t, err)
+
+		for _, authorization := range []string{mapiCallbackToken, "Bearer " + mapiCallbackToken} {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newMapiCallbackRequest(body, authorization))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.NotNil(t, (<-events).MerkleProof)
+		}
+	})
+
+	t.Run("unknown miner", func(t *testing.T) {
+		body, _ := newSignedMapiCallback(t, mapi.CallbackReasonMerkleProof, mapiCallbackMerkleProof)
+		handler, err := NewMapiCallbackHandler(mapiCallbackToken, []string{testMinerID}, nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newMapiCallbackRequest(body, mapiCallbackToken))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("token only accepts any miner", func(t *testing.T) {
+		body, _ := newSignedMapiCallback(t, mapi.CallbackReasonMerkleProof, mapiCallbackMerkleProof)
+		handler, err := NewMapiCallbackHandler(mapiCallbackToken, nil, func(_ context.Context, _ *MapiCallbackEvent) error {
+			return nil
+		})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newMapiCallbackRequest(body, mapiCallbackToken))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("miner ids only", func(t *testing.T) {
+		body, publicKey := newSignedMapiCallback(t, mapi.CallbackReasonMerkleProof, mapiCallbackMerkleProof)
+		handler, err := NewMapiCallbackHandler("", []string{publicKey}, func(_ context.Context, _ *MapiCallbackEvent) error {
+			return nil
+		})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newMapiCallbackRequest(body, ""))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// Signed by another key
+		body, _ = newSignedMapiCallback(t, mapi.CallbackReasonMerkleProof, mapiCallbackMerkleProof)
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, newMapiCallbackRequest(body, ""))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("no token and no miner ids", func(t *testing.T) {
+		handler, err := NewMapiCallbackHandler("", nil, func(_ context.Context, _ *MapiCallbackEvent) error {
+			return nil
+		})
+		require.ErrorIs(t, err, ErrMissingCallbackAuth)
+		assert.Nil(t, handler)
+
+		_, err = NewMapiCallbackChannelHandler("", nil, make(chan *MapiCallbackEvent))
+		require.ErrorIs(t, err, ErrMissingCallbackAuth)
+
+		// A zero value handler rejects everything
+		body, _ := newSignedMapiCallback(t, mapi.CallbackReasonMerkleProof, mapiCallbackMerkleProof)
+		w := httptest.NewRecorder()
+		(&MapiCallbackHandler{}).ServeHTTP(w, newMapiCallbackRequest(body, ""))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		body, publicKey := newSignedMapiCallback(t, mapi.CallbackReasonMerkleProof, mapiCallbackMerkleProof)
+		handler, err := NewMapiCallbackHandler(mapiCallbackToken, []string{publicKey}, nil)
+		require.NoError(t, err)
+
+		for _, authorization := range []string{"", "wrong-token", "Bearer wrong-token"} {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newMapiCallbackRequest(body, authorization))
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		}
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		handler, err := NewMapiCallbackHandler(mapiCallbackToken, nil, nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newMapiCallbackRequest([]byte(`{"payload":"{}"}`), mapiCallbackToken))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		handler, err := NewMapiCallbackHandler(mapiCallbackToken, nil, nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newMapiCallbackRequest([]byte("invalid"), mapiCallbackToken))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		handler, err := NewMapiCallbackHandler(mapiCallbackToken, nil, nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/callback", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		body, publicKey := newSignedMapiCallback(t, mapi.CallbackReasonDoubleSpend, mapiCallbackDoubleSpend)
+		handler, err := NewMapiCallbackHandler(mapiCallbackToken, []string{publicKey},
+			func(_ context.Context, _ *MapiCallbackEvent) error {
+				return errors.New("failed")
+			})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newMapiCallbackRequest(body, mapiCallbackToken))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
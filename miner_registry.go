@@ -0,0 +1,238 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UpdateMiner will update the name and/or the MinerID of a miner given its miner id
+//
+// Empty fields of the update are not changed. The miner APIs follow the new MinerID.
+func (c *Client) UpdateMiner(minerID string, update Miner) error {
+	c.registryLock.Lock()
+	defer c.registryLock.Unlock()
+
+	existing := MinerByID(c.miners, minerID)
+	if existing == nil {
+		return fmt.Errorf("miner %s not found", minerID)
+	}
+
+	updated := *existing
+	if len(update.Name) > 0 && update.Name != existing.Name {
+		// Names are compared case-insensitively, the miner can change the case of its own name
+		if other := MinerByName(c.miners, update.Name); other != nil && other.MinerID != existing.MinerID {
+			return fmt.Errorf("miner %s already exists", update.Name)
+		}
+		updated.Name = update.Name
+	}
+	if len(update.MinerID) > 0 && update.MinerID != existing.MinerID {
+		if !c.isUniqueMinerID(update.MinerID) || c.minerAPIsByMinerID(update.MinerID) != nil {
+			return fmt.Errorf("miner %s already exists", update.MinerID)
+		}
+		updated.MinerID = update.MinerID
+	}
+
+	// Replace the miner (copy-on-write, the previous lists can still be in use)
+	miners := c.minersCopy()
+	for i, miner := range miners {
+		if miner == existing {
+			miners[i] = &updated
+		}
+	}
+	c.miners = miners
+
+	if updated.MinerID != existing.MinerID {
+		c.healthMonitor.forget(existing.MinerID)
+		if minerAPIs := c.minerAPIsByMinerID(existing.MinerID); minerAPIs != nil {
+			c.replaceMinerAPIs(minerAPIs, func(apis *MinerAPIs) {
+				apis.MinerID = updated.MinerID
+			})
+		}
+	}
+	return nil
+}
+
+// AddMinerAPI will add an API to an existing miner (ex: an Arc endpoint to a mAPI miner)
+func (c *Client) AddMinerAPI(minerID string, api API) error {
+	c.registryLock.Lock()
+	defer c.registryLock.Unlock()
+
+	if MinerByID(c.miners, minerID) == nil {
+		return fmt.Errorf("miner %s not found", minerID)
+	}
+	if err := validateAPIs([]API{api}); err != nil {
+		return err
+	}
+
+	minerAPIs := c.minerAPIsByMinerID(minerID)
+	if minerAPIs == nil {
+		c.minerAPIs = append(append([]*MinerAPIs(nil), c.minerAPIs...), &MinerAPIs{
+			MinerID: minerID,
+			APIs:    []API{api},
+		})
+		return nil
+	}
+
+	if _, err := c.minerAPIByMinerID(minerID, api.Type); err == nil {
+		return fmt.Errorf("duplicate API type found: %s", api.Type)
+	}
+	c.replaceMinerAPIs(minerAPIs, func(updated *MinerAPIs) {
+		updated.APIs = append(updated.APIs, api)
+	})
+	return nil
+}
+
+// RemoveMinerAPI will remove an API from a miner (and from its preferred API types)
+//
+// The last API of a miner can't be removed, use RemoveMiner instead.
+func (c *Client) RemoveMinerAPI(minerID string, apiType APIType) error {
+	c.registryLock.Lock()
+	defer c.registryLock.Unlock()
+
+	if _, err := c.minerAPIByMinerID(minerID, apiType); err != nil {
+		return err
+	}
+
+	minerAPIs := c.minerAPIsByMinerID(minerID)
+	if len(minerAPIs.APIs) == 1 {
+		return errors.New("at least one API must be provided")
+	}
+
+	previous := minerAPIs.APIs
+	c.replaceMinerAPIs(minerAPIs, func(updated *MinerAPIs) {
+		apis := make([]API, 0, len(updated.APIs)-1)
+		for _, api := range updated.APIs {
+			if api.Type != apiType {
+				apis = append(apis, api)
+			}
+		}
+		updated.APIs = apis
+		updated.PreferredAPITypes = configuredAPITypes(updated.PreferredAPITypes, apis)
+	})
+	c.forgetRemovedAPIs(previous)
+	return nil
+}
+
+// SetMinerAPIs will replace all the APIs of a miner
+//
+// The preferred API types that are no longer configured are removed.
+func (c *Client) SetMinerAPIs(minerID string, apis []API) error {
+	c.registryLock.Lock()
+	defer c.registryLock.Unlock()
+
+	if MinerByID(c.miners, minerID) == nil {
+		return fmt.Errorf("miner %s not found", minerID)
+	}
+	if err := validateAPIs(apis); err != nil {
+		return err
+	}
+
+	minerAPIs := c.minerAPIsByMinerID(minerID)
+	if minerAPIs == nil {
+		c.minerAPIs = append(append([]*MinerAPIs(nil), c.minerAPIs...), &MinerAPIs{
+			MinerID: minerID,
+			APIs:    append([]API(nil), apis...),
+		})
+		return nil
+	}
+
+	previous := minerAPIs.APIs
+	c.replaceMinerAPIs(minerAPIs, func(updated *MinerAPIs) {
+		updated.APIs = append([]API(nil), apis...)
+		updated.PreferredAPITypes = configuredAPITypes(updated.PreferredAPITypes, apis)
+	})
+	c.forgetRemovedAPIs(previous)
+	return nil
+}
+
+// UpdateMinerToken will find a miner by name and update the token of the API type
+func (c *Client) UpdateMinerToken(name, token string, apiType APIType) error {
+	c.registryLock.Lock()
+	defer c.registryLock.Unlock()
+
+	miner := MinerByName(c.miners, name)
+	if miner == nil {
+		return fmt.Errorf("miner %s not found", name)
+	}
+
+	api, err := c.minerAPIByMinerID(miner.MinerID, apiType)
+	if err != nil {
+		return err
+	}
+
+	updated := *api
+	updated.Token = token
+	c.replaceMinerAPI(miner.MinerID, updated)
+	c.forgetRemovedAPIs([]API{*api})
+	return nil
+}
+
+// removeMinerAPIs will remove the APIs of a miner (lock must be held)
+func (c *Client) removeMinerAPIs(minerID string) {
+	var removed []API
+	list := make([]*MinerAPIs, 0, len(c.minerAPIs))
+	for _, minerAPIs := range c.minerAPIs {
+		if minerAPIs.MinerID != minerID {
+			list = append(list, minerAPIs)
+			continue
+		}
+		removed = append(removed, minerAPIs.APIs...)
+	}
+	c.minerAPIs = list
+	c.forgetRemovedAPIs(removed)
+}
+
+// forgetRemovedAPIs will clear the circuit breakers and rate limiters of the previous APIs
+// that are no longer configured for any miner (lock must be held)
+func (c *Client) forgetRemovedAPIs(previous []API) {
+	endpoints := make(map[string]bool)
+	tokens := make(map[string]bool)
+	for _, minerAPIs := range c.minerAPIs {
+		for _, api := range minerAPIs.APIs {
+			endpoints[api.URL] = true
+			tokens[api.URL+"|"+api.Token] = true
+		}
+	}
+
+	for _, api := range previous {
+		if !endpoints[api.URL] {
+			c.circuitBreakers.forget(api.URL)
+		}
+		if !tokens[api.URL+"|"+api.Token] {
+			c.rateLimiters.forget(api.URL, api.Token)
+		}
+	}
+}
+
+// validateAPIs will check that there is at least one API and that the API types are valid and unique
+func validateAPIs(apis []API) error {
+	if len(apis) == 0 {
+		return errors.New("at least one API must be provided")
+	}
+
+	apiTypes := make(map[APIType]bool)
+	for _, api := range apis {
+		if !isValidAPIType(api.Type) {
+			return fmt.Errorf("invalid API type: %s", api.Type)
+		}
+		if apiTypes[api.Type] {
+			return fmt.Errorf("duplicate API type found: %s", api.Type)
+		}
+		apiTypes[api.Type] = true
+	}
+	return nil
+}
+
+// configuredAPITypes will return the API types that are configured in the APIs
+func configuredAPITypes(apiTypes []APIType, apis []API) []APIType {
+	var configured []APIType
+	for _, apiType := range apiTypes {
+		for _, api := range apis {
+			if api.Type == apiType {
+				configured = append(configured, apiType)
+				break
+			}
+		}
+	}
+	return configured
+}
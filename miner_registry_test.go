@@ -0,0 +1,255 @@
+package minercraft
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRegistryClient returns a client with a test miner that only has a mAPI
+func newTestRegistryClient(t *testing.T) ClientInterface {
+	client := newTestClient(&mockHTTPDefaultClient{})
+	require.NoError(t, client.AddMiner(Miner{Name: testMinerName, MinerID: testMinerID},
+		[]API{{URL: testMinerURL, Type: MAPI, Token: testMinerToken}}))
+	return client
+}
+
+// TestClient_UpdateMiner tests the method UpdateMiner()
+func TestClient_UpdateMiner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("update the name", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.NoError(t, client.UpdateMiner(testMinerID, Miner{Name: "Renamed"}))
+		assert.Nil(t, client.MinerByName(testMinerName))
+		require.NotNil(t, client.MinerByName("Renamed"))
+		assert.Equal(t, testMinerID, client.MinerByName("Renamed").MinerID)
+	})
+
+	t.Run("change the case of the name", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.NoError(t, client.UpdateMiner(testMinerID, Miner{Name: strings.ToUpper(testMinerName)}))
+		assert.Equal(t, strings.ToUpper(testMinerName), client.MinerByID(testMinerID).Name)
+		require.Error(t, client.UpdateMiner(testMinerID, Miner{Name: strings.ToUpper(MinerTaal)}))
+	})
+
+	t.Run("update the miner id", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.NoError(t, client.UpdateMiner(testMinerID, Miner{MinerID: "new-id"}))
+		assert.Nil(t, client.MinerByID(testMinerID))
+		assert.Equal(t, testMinerName, client.MinerByID("new-id").Name)
+		assert.Nil(t, client.MinerAPIsByMinerID(testMinerID))
+
+		api, err := client.MinerAPIByMinerID("new-id", MAPI)
+		require.NoError(t, err)
+		assert.Equal(t, testMinerURL, api.URL)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.Error(t, client.UpdateMiner("unknown", Miner{Name: "Renamed"}))
+		require.Error(t, client.UpdateMiner(testMinerID, Miner{Name: MinerTaal}))
+		require.Error(t, client.UpdateMiner(testMinerID, Miner{MinerID: client.MinerByName(MinerTaal).MinerID}))
+	})
+}
+
+// TestClient_AddMinerAPI tests the method AddMinerAPI()
+func TestClient_AddMinerAPI(t *testing.T) {
+	t.Parallel()
+
+	t.Run("add an arc api", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.NoError(t, client.AddMinerAPI(testMinerID, API{URL: testMinerURL + "/arc", Type: Arc}))
+
+		api, err := client.MinerAPIByMinerID(testMinerID, Arc)
+		require.NoError(t, err)
+		assert.Equal(t, testMinerURL+"/arc", api.URL)
+		assert.Len(t, client.MinerAPIsByMinerID(testMinerID).APIs, 2)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.Error(t, client.AddMinerAPI("unknown", API{URL: testMinerURL, Type: Arc}))
+		require.Error(t, client.AddMinerAPI(testMinerID, API{URL: testMinerURL, Type: "invalid"}))
+		require.Error(t, client.AddMinerAPI(testMinerID, API{URL: testMinerURL, Type: MAPI}))
+	})
+}
+
+// TestClient_RemoveMinerAPI tests the method RemoveMinerAPI()
+func TestClient_RemoveMinerAPI(t *testing.T) {
+	t.Parallel()
+
+	t.Run("remove an api", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.NoError(t, client.AddMinerAPI(testMinerID, API{URL: testMinerURL, Type: Arc}))
+		require.NoError(t, client.SetMinerAPIPreference(testMinerID, Arc, MAPI))
+
+		require.NoError(t, client.RemoveMinerAPI(testMinerID, Arc))
+		_, err := client.MinerAPIByMinerID(testMinerID, Arc)
+		require.Error(t, err)
+		assert.Equal(t, []APIType{MAPI}, client.MinerAPIsByMinerID(testMinerID).PreferredAPITypes)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		var apiErr *APINotFoundError
+		require.ErrorAs(t, client.RemoveMinerAPI(testMinerID, Arc), &apiErr)
+		require.Error(t, client.RemoveMinerAPI(testMinerID, MAPI))
+	})
+}
+
+// TestClient_SetMinerAPIs tests the method SetMinerAPIs()
+func TestClient_SetMinerAPIs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("replace the apis", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.NoError(t, client.SetMinerAPIPreference(testMinerID, MAPI))
+		require.NoError(t, client.SetMinerAPIs(testMinerID, []API{{URL: testMinerURL, Type: Arc}}))
+
+		minerAPIs := client.MinerAPIsByMinerID(testMinerID)
+		require.Len(t, minerAPIs.APIs, 1)
+		assert.Equal(t, Arc, minerAPIs.APIs[0].Type)
+		assert.Empty(t, minerAPIs.PreferredAPITypes)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.Error(t, client.SetMinerAPIs("unknown", []API{{URL: testMinerURL, Type: Arc}}))
+		require.Error(t, client.SetMinerAPIs(testMinerID, nil))
+		require.Error(t, client.SetMinerAPIs(testMinerID, []API{{Type: Arc}, {Type: Arc}}))
+		require.Error(t, client.SetMinerAPIs(testMinerID, []API{{Type: "invalid"}}))
+	})
+}
+
+// TestClient_UpdateMinerToken tests the method UpdateMinerToken()
+func TestClient_UpdateMinerToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("update the token", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		previous, err := client.MinerAPIByMinerID(testMinerID, MAPI)
+		require.NoError(t, err)
+
+		require.NoError(t, client.UpdateMinerToken(testMinerName, "99999", MAPI))
+		api, err := client.MinerAPIByMinerID(testMinerID, MAPI)
+		require.NoError(t, err)
+		assert.Equal(t, "99999", api.Token)
+
+		// The previous API is not modified (it can still be in use)
+		assert.Equal(t, testMinerToken, previous.Token)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.Error(t, client.UpdateMinerToken("unknown", "99999", MAPI))
+
+		var apiErr *APINotFoundError
+		require.ErrorAs(t, client.UpdateMinerToken(testMinerName, "99999", Arc), &apiErr)
+	})
+
+	t.Run("missing api type does not panic", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		assert.NotPanics(t, func() {
+			client.MinerUpdateToken(testMinerName, "99999", Arc)
+		})
+	})
+}
+
+// TestClient_RemoveMiner_APIs tests that RemoveMiner() removes the miner APIs
+func TestClient_RemoveMiner_APIs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("apis are removed", func(t *testing.T) {
+		client := newTestRegistryClient(t)
+		require.True(t, client.RemoveMiner(client.MinerByName(testMinerName)))
+		assert.Nil(t, client.MinerAPIsByMinerID(testMinerID))
+
+		// Can be added again
+		require.NoError(t, client.AddMiner(Miner{Name: testMinerName, MinerID: testMinerID},
+			[]API{{URL: testMinerURL, Type: MAPI}}))
+	})
+
+	t.Run("shared miner id keeps the apis", func(t *testing.T) {
+		miners, err := DefaultMiners()
+		require.NoError(t, err)
+		apis, err := DefaultMinersAPIs()
+		require.NoError(t, err)
+		shared := &Miner{Name: "Shared", MinerID: miners[0].MinerID}
+
+		var client ClientInterface
+		client, err = NewClient(nil, &mockHTTPDefaultClient{}, MAPI, append(miners, shared), apis)
+		require.NoError(t, err)
+		require.True(t, client.RemoveMiner(shared))
+		assert.NotNil(t, client.MinerAPIsByMinerID(shared.MinerID))
+	})
+}
+
+// newTestStateClient returns a registry client with recorded circuit breaker, rate limiter and health state
+func newTestStateClient(t *testing.T) *Client {
+	options := DefaultClientOptions()
+	options.CircuitBreakerFailureThreshold = 1
+	client, err := createClient(options, MAPI, &mockHTTPDefaultClient{}, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.AddMiner(Miner{Name: testMinerName, MinerID: testMinerID}, []API{
+		{URL: testMinerURL, Type: MAPI, Token: testMinerToken},
+		{URL: testMinerURL + "/arc", Type: Arc},
+	}))
+
+	for _, api := range client.MinerAPIsByMinerID(testMinerID).APIs {
+		client.circuitBreakers.record(api.URL, false)
+		client.rateLimiters.pause(&httpPayload{Endpoint: api.URL, Token: api.Token}, time.Now().Add(time.Minute))
+	}
+	client.healthMonitor.record(client.MinerByID(testMinerID), healthProbe{}, nil)
+	return client
+}
+
+// TestClient_Registry_State tests that the registry changes clear the state of the removed miners and APIs
+func TestClient_Registry_State(t *testing.T) {
+	t.Parallel()
+
+	t.Run("remove miner", func(t *testing.T) {
+		client := newTestStateClient(t)
+		require.True(t, client.RemoveMiner(client.MinerByID(testMinerID)))
+		assert.Empty(t, client.circuitBreakers.breakers)
+		assert.Empty(t, client.rateLimiters.buckets)
+		assert.Empty(t, client.healthMonitor.miners)
+	})
+
+	t.Run("update the miner id", func(t *testing.T) {
+		client := newTestStateClient(t)
+		require.NoError(t, client.UpdateMiner(testMinerID, Miner{MinerID: "new-id"}))
+		assert.Empty(t, client.healthMonitor.miners)
+		assert.Len(t, client.circuitBreakers.breakers, 2)
+	})
+
+	t.Run("remove miner api", func(t *testing.T) {
+		client := newTestStateClient(t)
+		require.NoError(t, client.RemoveMinerAPI(testMinerID, Arc))
+		assert.Equal(t, CircuitOpen, client.circuitBreakers.state(testMinerURL))
+		assert.NotContains(t, client.circuitBreakers.breakers, testMinerURL+"/arc")
+		assert.Len(t, client.rateLimiters.buckets, 1)
+		assert.Len(t, client.healthMonitor.miners, 1)
+	})
+
+	t.Run("set miner apis with a new url", func(t *testing.T) {
+		client := newTestStateClient(t)
+		require.NoError(t, client.SetMinerAPIs(testMinerID, []API{
+			{URL: "https://new.example.com", Type: MAPI, Token: testMinerToken},
+			{URL: testMinerURL + "/arc", Type: Arc},
+		}))
+		assert.NotContains(t, client.circuitBreakers.breakers, testMinerURL)
+		assert.Contains(t, client.circuitBreakers.breakers, testMinerURL+"/arc")
+		assert.NotContains(t, client.rateLimiters.buckets, testMinerURL+"|"+testMinerToken)
+	})
+
+	t.Run("update the token", func(t *testing.T) {
+		client := newTestStateClient(t)
+		require.NoError(t, client.UpdateMinerToken(testMinerName, "new-token", MAPI))
+		assert.Contains(t, client.circuitBreakers.breakers, testMinerURL)
+		assert.NotContains(t, client.rateLimiters.buckets, testMinerURL+"|"+testMinerToken)
+	})
+}
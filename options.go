@@ -0,0 +1,124 @@
+package minercraft
+
+import "fmt"
+
+// Logger is used to log the retries and the circuit breakers of the client (ex: log.Default())
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// ClientOption defines an optional argument that can be passed to New
+type ClientOption func(c *clientConfig)
+
+// clientConfig is the configuration built by the client options
+type clientConfig struct {
+	apiType     APIType
+	httpClient  HTTPInterface
+	logger      Logger
+	minerAPIs   []*MinerAPIs
+	miners      []*Miner
+	options     *ClientOptions
+	retryPolicy *RetryPolicy
+	userAgent   string
+}
+
+// New creates a new client for requests using the given options
+//
+// Without options, the default ClientOptions, known miners and mAPI are used:
+//
+//	client, err := minercraft.New(
+//		minercraft.WithAPIType(minercraft.Arc),
+//		minercraft.WithUserAgent("my-app"),
+//	)
+func New(opts ...ClientOption) (ClientInterface, error) {
+	config := &clientConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	// Copy the options as they are modified
+	options := DefaultClientOptions()
+	if config.options != nil {
+		optionsCopy := *config.options
+		options = &optionsCopy
+	}
+	if config.retryPolicy != nil {
+		options.RetryPolicy = config.retryPolicy
+	}
+	if len(config.userAgent) > 0 {
+		options.UserAgent = config.userAgent
+	}
+	if config.logger != nil {
+		options.Logger = config.logger
+	}
+	if len(config.apiType) > 0 && !isValidAPIType(config.apiType) {
+		return nil, fmt.Errorf("invalid API type: %s", config.apiType)
+	}
+
+	c, err := createClient(options, config.apiType, config.httpClient, config.miners, config.minerAPIs)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// WithClientOptions will use the given options instead of the default options (see DefaultClientOptions)
+func WithClientOptions(options *ClientOptions) ClientOption {
+	return func(c *clientConfig) {
+		c.options = options
+	}
+}
+
+// WithHTTPClient will use the given HTTP client for all the requests
+//
+// The client is expected to handle its own retries, the requests are only retried
+// if WithRetryPolicy is also used.
+func WithHTTPClient(httpClient HTTPInterface) ClientOption {
+	return func(c *clientConfig) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIType will set the default API type of the client (defaults to mAPI)
+func WithAPIType(apiType APIType) ClientOption {
+	return func(c *clientConfig) {
+		c.apiType = apiType
+	}
+}
+
+// WithMiners will use the given miners and their APIs instead of the known miners
+// (both are required, otherwise the known miners are used)
+func WithMiners(miners []*Miner, minerAPIs []*MinerAPIs) ClientOption {
+	return func(c *clientConfig) {
+		c.miners = miners
+		c.minerAPIs = minerAPIs
+	}
+}
+
+// WithLogger will log the retries and the circuit breakers using the given logger (see ClientOptions.Logger)
+func WithLogger(logger Logger) ClientOption {
+	return func(c *clientConfig) {
+		c.logger = logger
+	}
+}
+
+// WithRetryPolicy will use the given retry policy for the requests (see RetryPolicy)
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *clientConfig) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithUserAgent will set the user agent of the requests
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *clientConfig) {
+		c.userAgent = userAgent
+	}
+}
+
+// logf will log the message if a logger is set
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+	}
+}
@@ -0,0 +1,136 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNew tests the method New()
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default client", func(t *testing.T) {
+		client, err := New()
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		assert.Len(t, client.Miners(), 2)
+		assert.Equal(t, MAPI, client.APIType())
+		assert.Equal(t, defaultUserAgent, client.UserAgent())
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		miners := []*Miner{{MinerID: testMinerID, Name: testMinerName}}
+		minerAPIs := []*MinerAPIs{{MinerID: testMinerID, APIs: []API{{Token: testMinerToken, URL: testMinerURL, Type: Arc}}}}
+		policy := testRetryPolicy()
+
+		client, err := New(
+			WithAPIType(Arc),
+			WithHTTPClient(http.DefaultClient),
+			WithMiners(miners, minerAPIs),
+			WithRetryPolicy(policy),
+			WithUserAgent("test-agent"),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		assert.Equal(t, Arc, client.APIType())
+		assert.Equal(t, "test-agent", client.UserAgent())
+		assert.Equal(t, http.DefaultClient, client.(*Client).httpClient)
+		assert.Equal(t, policy, client.(*Client).Options.RetryPolicy)
+		require.Len(t, client.Miners(), 1)
+		assert.Equal(t, testMinerName, client.Miners()[0].Name)
+	})
+
+	t.Run("client options are not modified", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.RequestRetryCount = 5
+
+		client, err := New(WithUserAgent("test-agent"), WithClientOptions(options))
+		require.NoError(t, err)
+
+		assert.Equal(t, "test-agent", client.UserAgent())
+		assert.Equal(t, 5, client.(*Client).Options.RequestRetryCount)
+		assert.Equal(t, defaultUserAgent, options.UserAgent)
+	})
+
+	t.Run("miners without apis", func(t *testing.T) {
+		client, err := New(WithMiners([]*Miner{{MinerID: testMinerID, Name: testMinerName}}, nil))
+		require.NoError(t, err)
+
+		// Falls back to the default miners
+		assert.Len(t, client.Miners(), 2)
+		assert.Nil(t, client.MinerByName(testMinerName))
+	})
+
+	t.Run("retries are logged", func(t *testing.T) {
+		var buf bytes.Buffer
+		httpClient := &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusBadGateway},
+			{statusCode: http.StatusOK, body: `{}`},
+		}}
+		client, err := New(
+			WithHTTPClient(httpClient),
+			WithLogger(log.New(&buf, "", 0)),
+			WithRetryPolicy(testRetryPolicy()),
+		)
+		require.NoError(t, err)
+
+		response := httpRequest(context.Background(), client.(*Client), &httpPayload{
+			APIType: MAPI, Method: http.MethodGet, URL: testMinerURL,
+		})
+		require.NoError(t, response.Error)
+		assert.Equal(t, 2, response.Attempts)
+		assert.Contains(t, buf.String(), "minercraft: retrying GET "+testMinerURL)
+	})
+
+	t.Run("invalid api type", func(t *testing.T) {
+		client, err := New(WithAPIType("invalid"))
+		require.Error(t, err)
+		assert.Nil(t, client)
+	})
+
+	t.Run("logger in the client options", func(t *testing.T) {
+		var buf bytes.Buffer
+		options := DefaultClientOptions()
+		options.Logger = log.New(&buf, "", 0)
+		options.RetryPolicy = testRetryPolicy()
+		client, err := NewClient(options, &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusBadGateway},
+			{statusCode: http.StatusOK, body: `{}`},
+		}}, MAPI, nil, nil)
+		require.NoError(t, err)
+
+		response := httpRequest(context.Background(), client.(*Client), &httpPayload{
+			APIType: MAPI, Method: http.MethodGet, URL: testMinerURL,
+		})
+		require.NoError(t, response.Error)
+		assert.Contains(t, buf.String(), "minercraft: retrying GET "+testMinerURL)
+	})
+}
+
+// ExampleNew example using New()
+func ExampleNew() {
+	client, err := New(WithAPIType(Arc), WithUserAgent("my-app"))
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("created new %s client with %d default miners", client.APIType(), len(client.Miners()))
+	// Output:created new Arc client with 2 default miners
+}
+
+// BenchmarkNew benchmarks the method New()
+func BenchmarkNew(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = New()
+	}
+}
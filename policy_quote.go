@@ -87,27 +87,23 @@ func (c *Client) PolicyQuote(ctx context.Context, miner *Miner) (*PolicyQuoteRes
 		return nil, errors.New("miner was nil")
 	}
 
-	route, err := ActionRouteByAPIType(PolicyQuote, c.apiType)
-	if err != nil {
-		return nil, err
-	}
-
 	// Make the HTTP request
-	result := getQuote(ctx, c, miner, route)
+	result := getQuote(ctx, c, miner, PolicyQuote)
 	if result.Response.Error != nil {
 		return nil, result.Response.Error
 	}
 
 	quoteResponse := &PolicyQuoteResponse{
 		JSONEnvelope: JSONEnvelope{
-			APIType: c.apiType,
+			APIType: result.APIType,
 			Miner:   result.Miner,
 		},
 	}
 
+	var err error
 	var modelAdapter PolicyQuoteModelAdapter
 
-	switch c.apiType {
+	switch result.APIType {
 	case MAPI:
 		model := &mapi.PolicyQuoteModel{}
 		err = quoteResponse.process(result.Miner, result.Response.BodyContents)
@@ -134,7 +130,7 @@ func (c *Client) PolicyQuote(ctx context.Context, miner *Miner) (*PolicyQuoteRes
 
 		modelAdapter = &PolicyQuoteArcAdapter{PolicyQuoteModel: model}
 	default:
-		return nil, fmt.Errorf("unknown API type: %s", c.apiType)
+		return nil, fmt.Errorf("unknown API type: %s", result.APIType)
 	}
 
 	quoteResponse.Quote = modelAdapter.GetPolicyData()
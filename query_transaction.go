@@ -98,7 +98,8 @@ type QueryTxResponse struct {
 	Timestamp   string `json:"timestamp,omitempty"`
 	TxID        string `json:"txid,omitempty"`
 	// ArcAPI specific fields
-	TxStatus arc.TxStatus `json:"txStatus,omitempty"`
+	CompetingTxs []string     `json:"competingTxs,omitempty"`
+	TxStatus     arc.TxStatus `json:"txStatus,omitempty"`
 	// mAPI specific fields
 	APIVersion            string          `json:"apiVersion,omitempty"`
 	ReturnResult          string          `json:"returnResult,omitempty"`
@@ -113,8 +114,10 @@ type QueryTxResponse struct {
 type QueryTransactionOptFunc func(o *queryTransactionOpts)
 
 type queryTransactionOpts struct {
-	includeProof bool
-	merkleFormat string
+	apiType       APIType
+	includeProof  bool
+	merkleFormat  string
+	minerSelector MinerSelector
 }
 
 func defaultQueryOpts() *queryTransactionOpts {
@@ -154,6 +157,21 @@ func WithQueryTSCMerkleFormat() QueryTransactionOptFunc {
 	}
 }
 
+// WithQueryMinerSelector will use the selector to pick the miner when the given miner is nil
+func WithQueryMinerSelector(selector MinerSelector) QueryTransactionOptFunc {
+	return func(o *queryTransactionOpts) {
+		o.minerSelector = selector
+	}
+}
+
+// WithQueryAPIType will query the transaction using the given API type instead of
+// the miner's preferred API type or the client API type.
+func WithQueryAPIType(apiType APIType) QueryTransactionOptFunc {
+	return func(o *queryTransactionOpts) {
+		o.apiType = apiType
+	}
+}
+
 // QueryTransaction will fire a Merchant API request to check the status of a transaction
 //
 // This endpoint is used to check the current status of a previously submitted transaction.
@@ -173,9 +191,20 @@ func WithQueryTSCMerkleFormat() QueryTransactionOptFunc {
 // Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi#4-query-transaction-status
 func (c *Client) QueryTransaction(ctx context.Context, miner *Miner, txID string, opts ...QueryTransactionOptFunc) (*QueryTransactionResponse, error) {
 
-	// Make sure we have a valid miner
+	// Make sure we have a valid miner (or select one)
 	if miner == nil {
-		return nil, errors.New("miner was nil")
+		queryOpts := defaultQueryOpts()
+		for _, opt := range opts {
+			opt(queryOpts)
+		}
+		if queryOpts.minerSelector == nil {
+			return nil, errors.New("miner was nil")
+		}
+
+		var err error
+		if miner, err = c.selectMiner(ctx, queryOpts.minerSelector, queryOpts.apiType); err != nil {
+			return nil, err
+		}
 	}
 
 	// Make the HTTP request
@@ -186,14 +215,14 @@ func (c *Client) QueryTransaction(ctx context.Context, miner *Miner, txID string
 
 	queryResponse := &QueryTransactionResponse{
 		JSONEnvelope: JSONEnvelope{
-			APIType: c.apiType,
+			APIType: result.APIType,
 			Miner:   result.Miner,
 		},
 	}
 
 	var modelAdapter QueryTxModelAdapter
 
-	switch c.apiType {
+	switch result.APIType {
 	case MAPI:
 		model := &mapi.QueryTxModel{}
 		err := queryResponse.process(result.Miner, result.Response.BodyContents)
@@ -222,7 +251,7 @@ func (c *Client) QueryTransaction(ctx context.Context, miner *Miner, txID string
 		modelAdapter = &QueryTxArcAdapter{QueryTxModel: model}
 
 	default:
-		return nil, fmt.Errorf("unknown API type: %s", c.apiType)
+		return nil, fmt.Errorf("unknown API type: %s", result.APIType)
 	}
 
 	queryResponse.Query = modelAdapter.GetQueryTxResponse()
@@ -253,7 +282,7 @@ func queryTransaction(ctx context.Context, client *Client, miner *Miner, txHash
 	}
 	sb := strings.Builder{}
 
-	api, err := client.MinerAPIByMinerID(miner.MinerID, client.apiType)
+	api, err := client.minerAPI(miner, defaultOpts.apiType)
 	if err != nil {
 		result = &internalResult{
 			Response: &RequestResponse{Error: err},
@@ -261,7 +290,7 @@ func queryTransaction(ctx context.Context, client *Client, miner *Miner, txHash
 		return
 	}
 
-	route, err := ActionRouteByAPIType(QueryTx, client.apiType)
+	route, err := ActionRouteByAPIType(QueryTx, api.Type)
 	if err != nil {
 		result = &internalResult{
 			Response: &RequestResponse{Error: err},
@@ -273,18 +302,14 @@ func queryTransaction(ctx context.Context, client *Client, miner *Miner, txHash
 	if defaultOpts.includeProof {
 		sb.WriteString("?merkleProof=true&merkleFormat=" + defaultOpts.merkleFormat)
 	}
-	result = &internalResult{Miner: miner}
+	result = &internalResult{APIType: api.Type, Miner: miner}
 	queryURL, err := url.Parse(sb.String())
 	if err != nil {
 		result.Response = &RequestResponse{Error: err}
 		return
 	}
 
-	result.Response = httpRequest(ctx, client, &httpPayload{
-		Method: http.MethodGet,
-		URL:    queryURL.String(),
-		Token:  api.Token,
-	})
+	result.Response = httpRequest(ctx, client, newAPIPayload(api, http.MethodGet, queryURL.String()))
 	return
 }
 
@@ -320,6 +345,7 @@ func (m *QueryTxArcAdapter) GetQueryTxResponse() *QueryTxResponse {
 
 	// Fields specific to ArcAPI
 	response.TxStatus = m.TxStatus
+	response.CompetingTxs = m.CompetingTxs
 
 	return response
 }
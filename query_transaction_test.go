@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tonicpow/go-minercraft/v2/apis/arc"
 )
 
 const queryTestSignature = "3044022066a8a39ff5f5eae818636aa03fdfc386ea4f33f41993cf41d4fb6d4745ae032102206a8895a6f742d809647ad1a1df12230e9b480275853ed28bc178f4b48afd802a"
@@ -105,6 +106,28 @@ func TestClient_QueryTransaction(t *testing.T) {
 		assert.Equal(t, QueryTransactionSuccess, response.Query.ReturnResult)
 	})
 
+	t.Run("query using arc for a single call", func(t *testing.T) {
+		client := newTestClient(&MockClient{MockDo: func(req *http.Request) (*http.Response, error) {
+			if req.URL.String() != "https://arc.gorillapool.io/v1/tx/"+testTx {
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBufferString(``))}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(bytes.NewBufferString(`{"blockHash":"0000000000000000050a09fe90b0e8542bba9e712edb8cc9349e61888fe45ac5",` +
+					`"blockHeight":612530,"timestamp":"2023-08-10T13:49:07.308687569Z","txStatus":"MINED","txid":"` + testTx + `"}`)),
+			}, nil
+		}})
+
+		response, err := client.QueryTransaction(
+			context.Background(), client.MinerByName(MinerGorillaPool), testTx, WithQueryAPIType(Arc),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, Arc, response.APIType)
+		assert.Equal(t, arc.Mined, response.Query.TxStatus)
+		assert.Equal(t, int64(612530), response.Query.BlockHeight)
+	})
+
 	t.Run("invalid miner", func(t *testing.T) {
 		client := newTestClient(&mockHTTPValidFeeQuote{})
 		response, err := client.QueryTransaction(context.Background(), nil, testTx)
@@ -0,0 +1,112 @@
+package minercraft
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// tokenBucket is the rate limiter of an API token
+type tokenBucket struct {
+	burst       float64
+	last        time.Time
+	pausedUntil time.Time // Set when the API returns a Retry-After
+	rate        float64
+	tokens      float64
+}
+
+// rateLimiters are the rate limiters of the miner APIs (by API URL and token)
+type rateLimiters struct {
+	sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// newRateLimiters will return the rate limiters
+func newRateLimiters() *rateLimiters {
+	return &rateLimiters{buckets: make(map[string]*tokenBucket)}
+}
+
+// bucket will return the bucket of the payload with its settings and tokens up-to-date (lock must be held)
+func (r *rateLimiters) bucket(payload *httpPayload, now time.Time) *tokenBucket {
+	key := payload.Endpoint + "|" + payload.Token
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{last: now}
+		r.buckets[key] = bucket
+	}
+
+	burst := math.Max(float64(payload.RateBurst), 1)
+	if bucket.rate != payload.RateLimit || bucket.burst != burst {
+		bucket.rate = payload.RateLimit
+		bucket.burst = burst
+		if !ok {
+			bucket.tokens = burst
+		}
+	}
+
+	// Refill the tokens
+	if bucket.rate > 0 {
+		bucket.tokens = math.Min(bucket.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*bucket.rate)
+	}
+	bucket.last = now
+	return bucket
+}
+
+// wait will wait until the request of the payload can be sent (or the context is done)
+func (r *rateLimiters) wait(ctx context.Context, payload *httpPayload) error {
+	if r == nil || len(payload.Endpoint) == 0 {
+		return nil
+	}
+
+	for {
+		r.Lock()
+		now := time.Now()
+		bucket := r.bucket(payload, now)
+
+		var wait time.Duration
+		switch {
+		case now.Before(bucket.pausedUntil):
+			wait = bucket.pausedUntil.Sub(now)
+		case bucket.rate <= 0:
+			r.Unlock()
+			return nil
+		case bucket.tokens >= 1:
+			bucket.tokens--
+			r.Unlock()
+			return nil
+		default:
+			wait = time.Duration((1 - bucket.tokens) / bucket.rate * float64(time.Second))
+		}
+		r.Unlock()
+
+		if !sleepContext(ctx, wait) {
+			return ctx.Err()
+		}
+	}
+}
+
+// forget will remove the bucket of the endpoint and token (the API was removed or its token changed)
+func (r *rateLimiters) forget(endpoint, token string) {
+	if r == nil {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	delete(r.buckets, endpoint+"|"+token)
+}
+
+// pause will stop the requests of the payload until the given time (429 with a Retry-After)
+func (r *rateLimiters) pause(payload *httpPayload, until time.Time) {
+	if r == nil || len(payload.Endpoint) == 0 {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+
+	bucket := r.bucket(payload, time.Now())
+	if until.After(bucket.pausedUntil) {
+		bucket.pausedUntil = until
+	}
+	bucket.tokens = 0
+}
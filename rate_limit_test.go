@@ -0,0 +1,163 @@
+package minercraft
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRateLimiters tests the token bucket rate limiters
+func TestRateLimiters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no limit", func(t *testing.T) {
+		limiters := newRateLimiters()
+		payload := &httpPayload{Endpoint: testMinerURL}
+		start := time.Now()
+		for i := 0; i < 100; i++ {
+			require.NoError(t, limiters.wait(context.Background(), payload))
+		}
+		assert.Less(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("burst then rate", func(t *testing.T) {
+		limiters := newRateLimiters()
+		payload := &httpPayload{Endpoint: testMinerURL, RateLimit: 20, RateBurst: 2}
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			require.NoError(t, limiters.wait(context.Background(), payload))
+		}
+		// 2 requests of burst, then 2 requests at 20 per second
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+
+	t.Run("tokens have their own bucket", func(t *testing.T) {
+		limiters := newRateLimiters()
+		first := &httpPayload{Endpoint: testMinerURL, Token: "first", RateLimit: 1}
+		second := &httpPayload{Endpoint: testMinerURL, Token: "second", RateLimit: 1}
+		require.NoError(t, limiters.wait(context.Background(), first))
+
+		start := time.Now()
+		require.NoError(t, limiters.wait(context.Background(), second))
+		assert.Less(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("context is done", func(t *testing.T) {
+		limiters := newRateLimiters()
+		payload := &httpPayload{Endpoint: testMinerURL, RateLimit: 0.1}
+		require.NoError(t, limiters.wait(context.Background(), payload))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, limiters.wait(ctx, payload), context.DeadlineExceeded)
+	})
+
+	t.Run("pause", func(t *testing.T) {
+		limiters := newRateLimiters()
+		payload := &httpPayload{Endpoint: testMinerURL}
+		limiters.pause(payload, time.Now().Add(50*time.Millisecond))
+
+		start := time.Now()
+		require.NoError(t, limiters.wait(context.Background(), payload))
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	})
+}
+
+// TestClient_RateLimit tests the rate limit of the miner APIs
+func TestClient_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	tx := &Transaction{RawTx: submitTestExampleTx}
+
+	t.Run("api rate limit", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		err := client.AddMiner(Miner{Name: testMinerName, MinerID: testMinerID},
+			[]API{{URL: testMinerURL, Type: MAPI, RateLimit: 20}})
+		require.NoError(t, err)
+		miner := client.MinerByName(testMinerName)
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			_, err = client.SubmitTransaction(context.Background(), miner, tx)
+			require.NoError(t, err)
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+
+	t.Run("retry after pauses the api", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.RequestRetryCount = 0
+		client, err := createClient(options, MAPI, &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "1"}},
+			{statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionSuccess, false)},
+		}}, nil, nil)
+		require.NoError(t, err)
+		miner := client.MinerByName(MinerTaal)
+
+		_, err = client.SubmitTransaction(context.Background(), miner, tx)
+		require.Error(t, err)
+
+		// Waits for the Retry-After
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err = client.SubmitTransaction(ctx, miner, tx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// Other APIs are not paused
+		_, err = client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), tx)
+		require.NoError(t, err)
+	})
+
+	t.Run("retry after pause is capped", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.RetryPolicy = testRetryPolicy()
+		options.RetryPolicy.MaxRetries = 0
+		options.RetryPolicy.MaxRetryAfter = 50 * time.Millisecond
+		client, err := createClient(options, MAPI, &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "3600"}},
+			{statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionSuccess, false)},
+		}}, nil, nil)
+		require.NoError(t, err)
+		miner := client.MinerByName(MinerTaal)
+
+		_, err = client.SubmitTransaction(context.Background(), miner, tx)
+		require.Error(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err = client.SubmitTransaction(ctx, miner, tx)
+		require.NoError(t, err)
+	})
+
+	t.Run("rate limit wait releases the half open probe", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.RequestRetryCount = 0
+		options.CircuitBreakerFailureThreshold = 1
+		options.CircuitBreakerOpenTimeout = 10 * time.Millisecond
+		client, err := createClient(options, MAPI, &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusServiceUnavailable},
+		}}, nil, nil)
+		require.NoError(t, err)
+		err = client.AddMiner(Miner{Name: testMinerName, MinerID: testMinerID},
+			[]API{{URL: testMinerURL, Type: MAPI, RateLimit: 0.1}})
+		require.NoError(t, err)
+		miner := client.MinerByName(testMinerName)
+
+		_, err = client.SubmitTransaction(context.Background(), miner, tx)
+		require.Error(t, err)
+		time.Sleep(20 * time.Millisecond)
+		require.Equal(t, CircuitHalfOpen, client.CircuitState(miner, ""))
+
+		// The probe is given back when the rate limit wait fails
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err = client.SubmitTransaction(ctx, miner, tx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, CircuitHalfOpen, client.CircuitState(miner, ""))
+		assert.Equal(t, 0, client.circuitBreakers.breakers[testMinerURL].probes)
+	})
+}
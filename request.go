@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // Retryable can be implemented to identify a struct as retryable, in this case an error can be deemed retryable.
@@ -82,15 +83,33 @@ type RequestResponse struct {
 	PostData     string `json:"post_data"`     // PostData is the post data submitted if POST/PUT request
 	StatusCode   int    `json:"status_code"`   // StatusCode is the last code from the request
 	URL          string `json:"url"`           // URL is used for the request
+	Attempts     int    `json:"attempts"`      // Attempts is the number of requests made (including retries)
 }
 
 // httpPayload is used for a httpRequest
 type httpPayload struct {
-	Method  string            `json:"method"`
-	URL     string            `json:"url"`
-	Token   string            `json:"token"`
-	Data    []byte            `json:"data"`
-	Headers map[string]string `json:"headers"`
+	APIType   APIType           `json:"apiType"`  // Used to parse the error responses
+	Endpoint  string            `json:"endpoint"` // Miner API URL (used for the circuit breaker and rate limiter)
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Token     string            `json:"token"`
+	Data      []byte            `json:"data"`
+	Headers   map[string]string `json:"headers"`
+	RateLimit float64           `json:"rateLimit"` // Requests per second of the API token (0 is no limit)
+	RateBurst int               `json:"rateBurst"`
+}
+
+// newAPIPayload will return the httpPayload for a request to the miner API
+func newAPIPayload(api *API, method, url string) *httpPayload {
+	return &httpPayload{
+		APIType:   api.Type,
+		Endpoint:  api.URL,
+		Method:    method,
+		URL:       url,
+		Token:     api.Token,
+		RateLimit: api.RateLimit,
+		RateBurst: api.RateBurst,
+	}
 }
 
 // httpRequest is a generic request wrapper that can be used without constraints.
@@ -99,6 +118,9 @@ type httpPayload struct {
 // this means the request returned an intermittent / transient error and can be retried depending on client
 // requirements.
 //
+// For Arc the error is an ArcErrorResponse which can be checked with errors.Is against the Arc
+// status codes (ex: ErrArcFeeTooLow).
+//
 // It can also be converted to the ErrorResponse type to get the error detail as shown:
 //
 //		var errResp ErrorResponse
@@ -109,15 +131,78 @@ type httpPayload struct {
 func httpRequest(ctx context.Context, client *Client,
 	payload *httpPayload) (response *RequestResponse) {
 
+	policy := client.retryPolicy()
+	for retry := 0; ; retry++ {
+
+		// Fail fast if the miner API is failing
+		if err := client.circuitBreakers.allow(payload.Endpoint); err != nil {
+			if response == nil {
+				response = &RequestResponse{Error: err, Method: payload.Method, URL: payload.URL}
+			}
+			return
+		}
+
+		// Wait for the rate limit of the API token
+		if err := client.rateLimiters.wait(ctx, payload); err != nil {
+			client.circuitBreakers.release(payload.Endpoint)
+			if response == nil {
+				response = &RequestResponse{Error: err, Method: payload.Method, URL: payload.URL}
+			}
+			return
+		}
+
+		var attempt *httpAttempt
+		response, attempt = doHTTPRequest(ctx, client, payload)
+		response.Attempts = retry + 1
+		if response.Error != nil && ctx.Err() != nil {
+			// A canceled attempt says nothing about the miner API
+			client.circuitBreakers.release(payload.Endpoint)
+		} else if client.circuitBreakers.record(payload.Endpoint, !isCircuitFailure(response, attempt)) {
+			client.logf("minercraft: circuit breaker opened for %s", payload.Endpoint)
+		}
+
+		// Slow down when the API is throttling the requests
+		if response.StatusCode == http.StatusTooManyRequests {
+			if retryAfter, ok := parseRetryAfter(attempt.header.Get("Retry-After")); ok {
+				if policy.MaxRetryAfter > 0 && retryAfter > policy.MaxRetryAfter {
+					retryAfter = policy.MaxRetryAfter
+				}
+				client.rateLimiters.pause(payload, time.Now().Add(retryAfter))
+			}
+		}
+
+		wait, ok := policy.waitBeforeRetry(ctx, payload, response, attempt, retry+1)
+		if !ok {
+			return
+		}
+		client.logf("minercraft: retrying %s %s in %s (attempt %d, status %d): %v",
+			payload.Method, payload.URL, wait, retry+2, response.StatusCode, response.Error)
+		if !sleepContext(ctx, wait) {
+			return
+		}
+	}
+}
+
+// httpAttempt is the information of a request attempt needed to decide on a retry
+type httpAttempt struct {
+	header         http.Header
+	transportError bool
+}
+
+// doHTTPRequest will make a single http request
+func doHTTPRequest(ctx context.Context, client *Client,
+	payload *httpPayload) (response *RequestResponse, attempt *httpAttempt) {
+
 	// Set reader
 	var bodyReader io.Reader
 
 	// Start the response
 	response = new(RequestResponse)
+	attempt = new(httpAttempt)
 
 	// Add post data if applicable
 	if payload.Method == http.MethodPost || payload.Method == http.MethodPut {
-		bodyReader = bytes.NewBuffer(payload.Data)
+		bodyReader = bytes.NewReader(payload.Data)
 		response.PostData = string(payload.Data)
 	}
 
@@ -140,8 +225,9 @@ func httpRequest(ctx context.Context, client *Client,
 	// Change the header (user agent is in case they block default Go user agents)
 	request.Header.Set("User-Agent", client.Options.UserAgent)
 
-	// Set the content type on Method
-	if payload.Method == http.MethodPost || payload.Method == http.MethodPut {
+	// Set the content type on Method (unless set in the headers)
+	if (payload.Method == http.MethodPost || payload.Method == http.MethodPut) &&
+		len(request.Header.Get("Content-Type")) == 0 {
 		request.Header.Set("Content-Type", "application/json")
 	}
 
@@ -153,9 +239,16 @@ func httpRequest(ctx context.Context, client *Client,
 	// Fire the http request
 	var resp *http.Response
 	if resp, response.Error = client.httpClient.Do(request); response.Error != nil {
+		attempt.transportError = true
 		if resp != nil {
 			response.StatusCode = resp.StatusCode
 		}
+
+		// The default client returns an untyped error, mark the transport errors as retryable
+		// (unless the request was canceled)
+		if ctx.Err() == nil {
+			response.Error = ErrRetryable{err: response.Error}
+		}
 		return
 	}
 
@@ -166,6 +259,7 @@ func httpRequest(ctx context.Context, client *Client,
 
 	// Set the status
 	response.StatusCode = resp.StatusCode
+	attempt.header = resp.Header
 
 	if resp.Body != nil {
 		// Read the body
@@ -176,11 +270,17 @@ func httpRequest(ctx context.Context, client *Client,
 		return
 	}
 
+	// Arc errors have their own format and retryable status codes
+	if payload.APIType == Arc {
+		response.Error = arcRequestError(resp.StatusCode, response.BodyContents)
+		return
+	}
+
 	// indicates that resubmitting this request could be successful when mAPI
 	// is available again.
 	retryable := response.StatusCode >= 500 && response.StatusCode <= 599
 	// unexpected status, write an error.
-	if response.BodyContents == nil {
+	if len(response.BodyContents) == 0 {
 		// There's no "body" present, so just echo status code.
 		statusErr := fmt.Errorf("status code: %d does not match %d", resp.StatusCode, http.StatusOK)
 		if !retryable {
@@ -203,3 +303,25 @@ func httpRequest(ctx context.Context, client *Client,
 	response.Error = errBody
 	return
 }
+
+// arcRequestError will map the Arc error response to an ArcErrorResponse
+//
+// The error is wrapped in ErrRetryable for 5xx and retryable Arc status codes.
+func arcRequestError(statusCode int, body []byte) error {
+	errBody := ArcErrorResponse{Status: statusCode}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &errBody); err != nil {
+			errBody.Detail = string(body)
+		}
+		// Always use the HTTP status code
+		errBody.Status = statusCode
+	}
+	if len(errBody.Title) == 0 {
+		errBody.Title = http.StatusText(statusCode)
+	}
+
+	if isRetryableArcStatus(statusCode) {
+		return ErrRetryable{err: errBody}
+	}
+	return errBody
+}
@@ -0,0 +1,179 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultMaxRetryAfter is the longest Retry-After the client will wait for
+const defaultMaxRetryAfter = 10 * time.Second
+
+// RetryPolicy is the retry configuration of the requests
+//
+// GET requests are retried on transport errors, 429, 5xx and retryable errors (see IsRetryable).
+// Submit requests are only retried on transport errors, 429, 5xx or when mAPI returns
+// FailureRetryable. The Retry-After header is honored on 429 and 503, the requests are
+// not retried past the context deadline.
+//
+// With a custom HTTP client (see NewClient and WithHTTPClient) the requests are only retried
+// if a RetryPolicy is set, as the custom client is expected to handle its own retries.
+type RetryPolicy struct {
+	BackOffExponentFactor        float64       `json:"back_off_exponent_factor"`
+	BackOffInitialTimeout        time.Duration `json:"back_off_initial_timeout"`
+	BackOffMaximumJitterInterval time.Duration `json:"back_off_maximum_jitter_interval"`
+	BackOffMaxTimeout            time.Duration `json:"back_off_max_timeout"`
+	MaxRetries                   int           `json:"max_retries"`     // Retries after the first attempt (0 disables the retries)
+	MaxRetryAfter                time.Duration `json:"max_retry_after"` // Longer Retry-After values are not waited for
+}
+
+// retryPolicy will return the retry policy of the options
+//
+// If no RetryPolicy is set, it's created from the RequestRetryCount and BackOff options.
+func (o *ClientOptions) retryPolicy() *RetryPolicy {
+	if o.RetryPolicy != nil {
+		return o.RetryPolicy
+	}
+	return &RetryPolicy{
+		BackOffExponentFactor:        o.BackOffExponentFactor,
+		BackOffInitialTimeout:        o.BackOffInitialTimeout,
+		BackOffMaximumJitterInterval: o.BackOffMaximumJitterInterval,
+		BackOffMaxTimeout:            o.BackOffMaxTimeout,
+		MaxRetries:                   o.RequestRetryCount,
+		MaxRetryAfter:                defaultMaxRetryAfter,
+	}
+}
+
+// retryPolicy will return the retry policy of the requests
+//
+// A custom HTTP client handles its own retries, it's only retried with an explicit RetryPolicy.
+func (c *Client) retryPolicy() *RetryPolicy {
+	if c.customHTTPClient && c.Options.RetryPolicy == nil {
+		return &RetryPolicy{MaxRetryAfter: defaultMaxRetryAfter}
+	}
+	return c.Options.retryPolicy()
+}
+
+// backOff will return the time to wait before the given retry (starting at 1)
+func (p *RetryPolicy) backOff(retry int) time.Duration {
+	factor := p.BackOffExponentFactor
+	if factor < 1 {
+		factor = 1
+	}
+	wait := time.Duration(float64(p.BackOffInitialTimeout) * math.Pow(factor, float64(retry-1)))
+	if p.BackOffMaxTimeout > 0 && wait > p.BackOffMaxTimeout {
+		wait = p.BackOffMaxTimeout
+	}
+	if p.BackOffMaximumJitterInterval > 0 {
+		wait += time.Duration(rand.Int63n(int64(p.BackOffMaximumJitterInterval))) //nolint:gosec // jitter only
+	}
+	return wait
+}
+
+// waitBeforeRetry will return the time to wait before retrying the request, false if it should not be retried
+func (p *RetryPolicy) waitBeforeRetry(ctx context.Context, payload *httpPayload, response *RequestResponse,
+	attempt *httpAttempt, retry int) (time.Duration, bool) {
+
+	if retry > p.MaxRetries || ctx.Err() != nil || !isRetryableAttempt(payload, response, attempt) {
+		return 0, false
+	}
+
+	wait := p.backOff(retry)
+	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusServiceUnavailable {
+		if retryAfter, ok := parseRetryAfter(attempt.header.Get("Retry-After")); ok {
+			if p.MaxRetryAfter > 0 && retryAfter > p.MaxRetryAfter {
+				return 0, false
+			}
+			wait = retryAfter
+		}
+	}
+
+	// Give up if the wait goes past the deadline
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= wait {
+		return 0, false
+	}
+	return wait, true
+}
+
+// isRetryableAttempt will return true if the request can be sent again
+func isRetryableAttempt(payload *httpPayload, response *RequestResponse, attempt *httpAttempt) bool {
+	if isContextError(response.Error) {
+		return false
+	}
+
+	switch {
+	case attempt.transportError:
+		return true
+	case response.StatusCode == http.StatusTooManyRequests:
+		return true
+	case response.StatusCode >= 500 && response.StatusCode <= 599:
+		return true
+	}
+
+	// Queries can always be retried
+	if payload.Method == http.MethodGet {
+		return response.Error != nil && IsRetryable(response.Error)
+	}
+
+	// Submits are only retried when mAPI says so
+	return response.Error == nil && payload.APIType == MAPI && mapiFailureRetryable(response.BodyContents)
+}
+
+// isContextError will return true if the error is a context cancellation or deadline
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// mapiFailureRetryable will return true if the mAPI submit response payload has failureRetryable
+func mapiFailureRetryable(body []byte) bool {
+	var envelope struct {
+		Payload string `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || len(envelope.Payload) == 0 {
+		return false
+	}
+
+	var payload struct {
+		FailureRetryable bool   `json:"failureRetryable"`
+		ReturnResult     string `json:"returnResult"`
+	}
+	if err := json.Unmarshal([]byte(envelope.Payload), &payload); err != nil {
+		return false
+	}
+	return payload.ReturnResult == QueryTransactionFailure && payload.FailureRetryable
+}
+
+// parseRetryAfter will parse the Retry-After header (seconds or http date)
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if len(value) == 0 {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// sleepContext will wait for the duration or until the context is done
+func sleepContext(ctx context.Context, wait time.Duration) bool {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,274 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockResponse is a response of mockHTTPSequence
+type mockResponse struct {
+	body       string
+	err        error
+	headers    map[string]string
+	statusCode int
+}
+
+// mockHTTPSequence for mocking a sequence of responses (the last one is repeated)
+type mockHTTPSequence struct {
+	sync.Mutex
+	calls     int
+	responses []mockResponse
+}
+
+// Do is a mock http request
+func (m *mockHTTPSequence) Do(_ *http.Request) (*http.Response, error) {
+	m.Lock()
+	defer m.Unlock()
+	response := m.responses[len(m.responses)-1]
+	if m.calls < len(m.responses) {
+		response = m.responses[m.calls]
+	}
+	m.calls++
+
+	if response.err != nil {
+		return nil, response.err
+	}
+	resp := &http.Response{
+		StatusCode: response.statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewBufferString(response.body)),
+	}
+	for key, value := range response.headers {
+		resp.Header.Set(key, value)
+	}
+	return resp, nil
+}
+
+// newTestRetryClient returns a client with a fast retry policy
+func newTestRetryClient(t *testing.T, httpClient HTTPInterface, policy *RetryPolicy) *Client {
+	options := DefaultClientOptions()
+	options.RetryPolicy = policy
+	client, err := createClient(options, MAPI, httpClient, nil, nil)
+	require.NoError(t, err)
+	return client
+}
+
+// testRetryPolicy is a fast retry policy for the tests
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		BackOffExponentFactor: 2,
+		BackOffInitialTimeout: time.Millisecond,
+		BackOffMaxTimeout:     5 * time.Millisecond,
+		MaxRetries:            2,
+		MaxRetryAfter:         2 * time.Second,
+	}
+}
+
+// mapiSubmitBody returns a mAPI submit response with the given result
+func mapiSubmitBody(returnResult string, failureRetryable bool) string {
+	retryable := "false"
+	if failureRetryable {
+		retryable = "true"
+	}
+	return `{"payload":"{\"returnResult\":\"` + returnResult + `\",\"failureRetryable\":` + retryable + `}"}`
+}
+
+// TestHTTPRequest_Retry tests the retry policy of httpRequest()
+func TestHTTPRequest_Retry(t *testing.T) {
+	t.Parallel()
+
+	get := func() *httpPayload {
+		return &httpPayload{APIType: MAPI, Method: http.MethodGet, URL: testMinerURL}
+	}
+	post := func() *httpPayload {
+		return &httpPayload{APIType: MAPI, Method: http.MethodPost, URL: testMinerURL, Data: []byte(`{}`)}
+	}
+
+	t.Run("custom http client without a retry policy", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusBadGateway},
+			{statusCode: http.StatusOK, body: `{}`},
+		}}
+		client, err := createClient(nil, MAPI, httpClient, nil, nil)
+		require.NoError(t, err)
+
+		// The custom client handles its own retries
+		response := httpRequest(context.Background(), client, get())
+		require.Error(t, response.Error)
+		assert.Equal(t, 1, response.Attempts)
+		assert.Equal(t, 1, httpClient.calls)
+	})
+
+	t.Run("default http client uses the retry options", func(t *testing.T) {
+		client, err := createClient(nil, MAPI, nil, nil, nil)
+		require.NoError(t, err)
+		assert.False(t, client.customHTTPClient)
+		assert.Equal(t, client.Options.RequestRetryCount, client.retryPolicy().MaxRetries)
+	})
+
+	t.Run("no retry on success", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusOK, body: `{}`}}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), get())
+		require.NoError(t, response.Error)
+		assert.Equal(t, 1, response.Attempts)
+		assert.Equal(t, 1, httpClient.calls)
+	})
+
+	t.Run("get is retried on 5xx", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusBadGateway},
+			{statusCode: http.StatusOK, body: `{}`},
+		}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), get())
+		require.NoError(t, response.Error)
+		assert.Equal(t, 2, response.Attempts)
+	})
+
+	t.Run("get gives up after the max retries", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusInternalServerError}}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), get())
+		require.Error(t, response.Error)
+		assert.True(t, IsRetryable(response.Error))
+		assert.Equal(t, 3, response.Attempts)
+		assert.Equal(t, 3, httpClient.calls)
+	})
+
+	t.Run("get is not retried on 400", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusBadRequest, body: `{}`}}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), get())
+		require.Error(t, response.Error)
+		assert.Equal(t, 1, response.Attempts)
+	})
+
+	t.Run("retries disabled", func(t *testing.T) {
+		policy := testRetryPolicy()
+		policy.MaxRetries = 0
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusInternalServerError}}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, policy), get())
+		require.Error(t, response.Error)
+		assert.Equal(t, 1, response.Attempts)
+	})
+
+	t.Run("submit is retried on transport error", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{
+			{err: errors.New("connection reset")},
+			{statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionSuccess, false)},
+		}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), post())
+		require.NoError(t, response.Error)
+		assert.Equal(t, 2, response.Attempts)
+	})
+
+	t.Run("submit is retried on failure retryable", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionFailure, true)},
+			{statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionSuccess, false)},
+		}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), post())
+		require.NoError(t, response.Error)
+		assert.Equal(t, 2, response.Attempts)
+	})
+
+	t.Run("submit is not retried on a failure", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusOK, body: mapiSubmitBody(QueryTransactionFailure, false)},
+		}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), post())
+		require.NoError(t, response.Error)
+		assert.Equal(t, 1, response.Attempts)
+	})
+
+	t.Run("submit is not retried on 400", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusBadRequest, body: `{}`}}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), post())
+		require.Error(t, response.Error)
+		assert.Equal(t, 1, response.Attempts)
+	})
+
+	t.Run("arc submit is not retried on a retryable arc status", func(t *testing.T) {
+		payload := post()
+		payload.APIType = Arc
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: 469, body: `{"status":469}`}}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), payload)
+		require.Error(t, response.Error)
+		assert.Equal(t, 1, response.Attempts)
+	})
+
+	t.Run("retry after is honored", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "1"}},
+			{statusCode: http.StatusOK, body: `{}`},
+		}}
+		start := time.Now()
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), post())
+		require.NoError(t, response.Error)
+		assert.Equal(t, 2, response.Attempts)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("retry after above the max is not waited for", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{
+			{statusCode: http.StatusServiceUnavailable, headers: map[string]string{"Retry-After": "60"}},
+		}}
+		response := httpRequest(context.Background(), newTestRetryClient(t, httpClient, testRetryPolicy()), get())
+		require.Error(t, response.Error)
+		assert.Equal(t, 1, response.Attempts)
+	})
+
+	t.Run("gives up before the context deadline", func(t *testing.T) {
+		policy := testRetryPolicy()
+		policy.BackOffInitialTimeout = time.Second
+		policy.BackOffMaxTimeout = time.Second
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusInternalServerError}}}
+		start := time.Now()
+		response := httpRequest(ctx, newTestRetryClient(t, httpClient, policy), get())
+		require.Error(t, response.Error)
+		assert.Equal(t, 1, response.Attempts)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+// TestParseRetryAfter tests the method parseRetryAfter()
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	wait, ok := parseRetryAfter("5")
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+// TestClientOptions_RetryPolicy tests the method retryPolicy()
+func TestClientOptions_RetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	options := DefaultClientOptions()
+	policy := options.retryPolicy()
+	assert.Equal(t, options.RequestRetryCount, policy.MaxRetries)
+	assert.Equal(t, options.BackOffInitialTimeout, policy.BackOffInitialTimeout)
+	assert.Equal(t, defaultMaxRetryAfter, policy.MaxRetryAfter)
+
+	options.RetryPolicy = &RetryPolicy{MaxRetries: 5}
+	assert.Equal(t, 5, options.retryPolicy().MaxRetries)
+}
@@ -0,0 +1,253 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrNoMinerSelected is returned when a MinerSelector could not select a miner
+var ErrNoMinerSelected = errors.New("no miner selected")
+
+// MinerSelector selects the miner to use for a request (see WithSubmitMinerSelector
+// and WithQueryMinerSelector)
+//
+// The miners are the client miners that have an API for the request (without an open
+// circuit breaker), the selector must return one of them.
+type MinerSelector interface {
+	SelectMiner(ctx context.Context, client ClientInterface, miners []*Miner) (*Miner, error)
+}
+
+// MinerSelectorFunc is a function that can be used as a MinerSelector
+type MinerSelectorFunc func(ctx context.Context, client ClientInterface, miners []*Miner) (*Miner, error)
+
+// SelectMiner will call the function
+func (f MinerSelectorFunc) SelectMiner(ctx context.Context, client ClientInterface, miners []*Miner) (*Miner, error) {
+	return f(ctx, client, miners)
+}
+
+// selectMiner will select a miner that has an API of the given type (or any type if empty)
+func (c *Client) selectMiner(ctx context.Context, selector MinerSelector, apiType APIType) (*Miner, error) {
+	available := c.availableMiners(c.minersSnapshot(), apiType)
+	miners := make([]*Miner, 0, len(available))
+	for _, miner := range available {
+		if _, err := c.minerAPI(miner, apiType); err == nil {
+			miners = append(miners, miner)
+		}
+	}
+	if len(miners) == 0 {
+		return nil, ErrNoMinerSelected
+	}
+
+	miner, err := selector.SelectMiner(ctx, c, miners)
+	if err != nil {
+		return nil, err
+	}
+	if miner == nil {
+		return nil, ErrNoMinerSelected
+	}
+	return miner, nil
+}
+
+// roundRobinSelector selects the miners in turn
+type roundRobinSelector struct {
+	next atomic.Uint64
+}
+
+// NewRoundRobinSelector will return a MinerSelector that selects the miners in turn
+func NewRoundRobinSelector() MinerSelector {
+	return &roundRobinSelector{}
+}
+
+// SelectMiner will select the next miner
+func (s *roundRobinSelector) SelectMiner(_ context.Context, _ ClientInterface, miners []*Miner) (*Miner, error) {
+	if len(miners) == 0 {
+		return nil, ErrNoMinerSelected
+	}
+	return miners[(s.next.Add(1)-1)%uint64(len(miners))], nil
+}
+
+// weightedRandomSelector selects a random miner based on the weights
+type weightedRandomSelector struct {
+	weights map[string]int
+}
+
+// NewWeightedRandomSelector will return a MinerSelector that selects a random miner based on
+// the given weights (by miner name)
+//
+// Miners without a weight are never selected, unless no miner has a weight.
+func NewWeightedRandomSelector(weights map[string]int) MinerSelector {
+	return &weightedRandomSelector{weights: weights}
+}
+
+// SelectMiner will select a random miner
+func (s *weightedRandomSelector) SelectMiner(_ context.Context, _ ClientInterface, miners []*Miner) (*Miner, error) {
+	var total int
+	for _, miner := range miners {
+		if weight := s.weights[miner.Name]; weight > 0 {
+			total += weight
+		}
+	}
+	if total == 0 {
+		if len(miners) == 0 {
+			return nil, ErrNoMinerSelected
+		}
+		return miners[rand.Intn(len(miners))], nil //nolint:gosec // not used for security
+	}
+
+	pick := rand.Intn(total) //nolint:gosec // not used for security
+	for _, miner := range miners {
+		if weight := s.weights[miner.Name]; weight > 0 {
+			if pick < weight {
+				return miner, nil
+			}
+			pick -= weight
+		}
+	}
+	return nil, ErrNoMinerSelected
+}
+
+// prioritySelector selects the first available miner of the priority list
+type prioritySelector struct {
+	names []string
+}
+
+// NewPrioritySelector will return a MinerSelector that selects the first available miner of the
+// given miner names, or the first miner if none of them is available
+func NewPrioritySelector(names ...string) MinerSelector {
+	return &prioritySelector{names: names}
+}
+
+// SelectMiner will select the miner with the highest priority
+func (s *prioritySelector) SelectMiner(_ context.Context, _ ClientInterface, miners []*Miner) (*Miner, error) {
+	for _, name := range s.names {
+		for _, miner := range miners {
+			if miner.Name == name {
+				return miner, nil
+			}
+		}
+	}
+	if len(miners) == 0 {
+		return nil, ErrNoMinerSelected
+	}
+	return miners[0], nil
+}
+
+// minerStat is a cached value for a miner
+type minerStat struct {
+	expires time.Time
+	ok      bool
+	value   uint64
+}
+
+// minerStatsCache is a cache of a value per miner (by name)
+type minerStatsCache struct {
+	sync.Mutex
+	stats map[string]minerStat
+	ttl   time.Duration
+}
+
+// newMinerStatsCache will return a cache where the values expire after the ttl
+func newMinerStatsCache(ttl time.Duration) *minerStatsCache {
+	return &minerStatsCache{stats: make(map[string]minerStat), ttl: ttl}
+}
+
+// lowest will return the miner with the lowest value, the expired values are refreshed
+// concurrently using the load function (ok false if the miner can't be used)
+func (m *minerStatsCache) lowest(ctx context.Context, miners []*Miner,
+	load func(ctx context.Context, miner *Miner) (uint64, bool)) (*Miner, error) {
+
+	// Refresh the expired values (break into a Go routine for each miner)
+	var wg sync.WaitGroup
+	now := time.Now()
+	for _, miner := range miners {
+		m.Lock()
+		stat, found := m.stats[miner.Name]
+		m.Unlock()
+		if found && now.Before(stat.expires) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(miner *Miner) {
+			defer wg.Done()
+			value, ok := load(ctx, miner)
+			m.Lock()
+			defer m.Unlock()
+			m.stats[miner.Name] = minerStat{expires: time.Now().Add(m.ttl), ok: ok, value: value}
+		}(miner)
+	}
+
+	// Waiting for all requests to finish
+	wg.Wait()
+
+	m.Lock()
+	defer m.Unlock()
+	var lowest *Miner
+	var lowestValue uint64
+	for _, miner := range miners {
+		stat := m.stats[miner.Name]
+		if stat.ok && (lowest == nil || stat.value < lowestValue) {
+			lowest = miner
+			lowestValue = stat.value
+		}
+	}
+	if lowest == nil {
+		return nil, ErrNoMinerSelected
+	}
+	return lowest, nil
+}
+
+// lowestFeeSelector selects the miner with the lowest fee
+type lowestFeeSelector struct {
+	cache       *minerStatsCache
+	feeCategory string
+	feeType     string
+}
+
+// NewLowestFeeSelector will return a MinerSelector that selects the miner with the lowest fee
+// for the given fee category and type (see BestQuote)
+//
+// The fee quotes are cached for the ttl.
+func NewLowestFeeSelector(feeCategory, feeType string, ttl time.Duration) MinerSelector {
+	return &lowestFeeSelector{cache: newMinerStatsCache(ttl), feeCategory: feeCategory, feeType: feeType}
+}
+
+// SelectMiner will select the miner with the lowest fee
+func (s *lowestFeeSelector) SelectMiner(ctx context.Context, client ClientInterface, miners []*Miner) (*Miner, error) {
+	return s.cache.lowest(ctx, miners, func(ctx context.Context, miner *Miner) (uint64, bool) {
+		quote, err := client.FeeQuote(ctx, miner)
+		if err != nil || quote.Quote == nil {
+			return 0, false
+		}
+		fee, err := quote.Quote.CalculateFee(s.feeCategory, s.feeType, 1000)
+		return fee, err == nil
+	})
+}
+
+// lowestLatencySelector selects the healthy miner with the lowest latency
+type lowestLatencySelector struct {
+	cache *minerStatsCache
+}
+
+// NewLowestLatencySelector will return a MinerSelector that selects the healthy miner with
+// the lowest latency (see Health)
+//
+// The latencies are cached for the ttl.
+func NewLowestLatencySelector(ttl time.Duration) MinerSelector {
+	return &lowestLatencySelector{cache: newMinerStatsCache(ttl)}
+}
+
+// SelectMiner will select the miner with the lowest latency
+func (s *lowestLatencySelector) SelectMiner(ctx context.Context, client ClientInterface, miners []*Miner) (*Miner, error) {
+	return s.cache.lowest(ctx, miners, func(ctx context.Context, miner *Miner) (uint64, bool) {
+		health, err := client.Health(ctx, miner)
+		if err != nil || !health.Healthy {
+			return 0, false
+		}
+		return uint64(health.Latency), true
+	})
+}
@@ -0,0 +1,163 @@
+package minercraft
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tonicpow/go-minercraft/v2/apis/mapi"
+)
+
+// testSelectorMiners returns the miners for the selector tests
+func testSelectorMiners() []*Miner {
+	return []*Miner{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+}
+
+// TestNewRoundRobinSelector tests the method NewRoundRobinSelector()
+func TestNewRoundRobinSelector(t *testing.T) {
+	t.Parallel()
+
+	miners := testSelectorMiners()
+	selector := NewRoundRobinSelector()
+	for i := 0; i < 6; i++ {
+		miner, err := selector.SelectMiner(context.Background(), nil, miners)
+		require.NoError(t, err)
+		assert.Equal(t, miners[i%3], miner)
+	}
+
+	_, err := selector.SelectMiner(context.Background(), nil, nil)
+	require.ErrorIs(t, err, ErrNoMinerSelected)
+}
+
+// TestNewWeightedRandomSelector tests the method NewWeightedRandomSelector()
+func TestNewWeightedRandomSelector(t *testing.T) {
+	t.Parallel()
+
+	miners := testSelectorMiners()
+
+	t.Run("only weighted miners are selected", func(t *testing.T) {
+		selector := NewWeightedRandomSelector(map[string]int{"b": 1, "c": 3})
+		for i := 0; i < 50; i++ {
+			miner, err := selector.SelectMiner(context.Background(), nil, miners)
+			require.NoError(t, err)
+			assert.NotEqual(t, "a", miner.Name)
+		}
+	})
+
+	t.Run("no weights", func(t *testing.T) {
+		miner, err := NewWeightedRandomSelector(nil).SelectMiner(context.Background(), nil, miners)
+		require.NoError(t, err)
+		assert.Contains(t, miners, miner)
+	})
+}
+
+// TestNewPrioritySelector tests the method NewPrioritySelector()
+func TestNewPrioritySelector(t *testing.T) {
+	t.Parallel()
+
+	miners := testSelectorMiners()
+
+	miner, err := NewPrioritySelector("unknown", "c", "b").SelectMiner(context.Background(), nil, miners)
+	require.NoError(t, err)
+	assert.Equal(t, "c", miner.Name)
+
+	miner, err = NewPrioritySelector("unknown").SelectMiner(context.Background(), nil, miners)
+	require.NoError(t, err)
+	assert.Equal(t, "a", miner.Name)
+}
+
+// TestNewLowestFeeSelector tests the method NewLowestFeeSelector()
+func TestNewLowestFeeSelector(t *testing.T) {
+	t.Parallel()
+
+	t.Run("skips the miners without a quote", func(t *testing.T) {
+		client := newTestClient(&mockHTTPByHost{
+			fallback:  &mockHTTPValidFeeQuote{},
+			responses: map[string]mockResponse{"taal": {statusCode: http.StatusBadRequest, body: `{}`}},
+		})
+		selector := NewLowestFeeSelector(mapi.FeeCategoryMining, mapi.FeeTypeData, time.Minute)
+		miner, err := selector.SelectMiner(context.Background(), client, client.Miners())
+		require.NoError(t, err)
+		assert.Equal(t, MinerGorillaPool, miner.Name)
+	})
+
+	t.Run("quotes are cached", func(t *testing.T) {
+		httpClient := &mockHTTPSequence{responses: []mockResponse{{statusCode: http.StatusBadRequest, body: `{}`}}}
+		client := newTestClient(httpClient)
+		selector := NewLowestFeeSelector(mapi.FeeCategoryMining, mapi.FeeTypeData, time.Minute)
+		_, err := selector.SelectMiner(context.Background(), client, client.Miners())
+		require.ErrorIs(t, err, ErrNoMinerSelected)
+
+		calls := httpClient.calls
+		_, err = selector.SelectMiner(context.Background(), client, client.Miners())
+		require.ErrorIs(t, err, ErrNoMinerSelected)
+		assert.Equal(t, calls, httpClient.calls)
+	})
+}
+
+// TestNewLowestLatencySelector tests the method NewLowestLatencySelector()
+func TestNewLowestLatencySelector(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPByHost{
+		fallback:  &mockHTTPValidFeeQuote{},
+		responses: map[string]mockResponse{"gorillapool": {statusCode: http.StatusBadRequest, body: `{}`}},
+	})
+	miner, err := NewLowestLatencySelector(time.Minute).SelectMiner(context.Background(), client, client.Miners())
+	require.NoError(t, err)
+	assert.Equal(t, MinerTaal, miner.Name)
+}
+
+// TestClient_MinerSelector tests selecting the miner of the requests
+func TestClient_MinerSelector(t *testing.T) {
+	t.Parallel()
+
+	tx := &Transaction{RawTx: submitTestExampleTx}
+
+	t.Run("submit transaction", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		response, err := client.SubmitTransaction(context.Background(), nil, tx,
+			WithSubmitMinerSelector(NewPrioritySelector(MinerGorillaPool)))
+		require.NoError(t, err)
+		assert.Equal(t, MinerGorillaPool, response.Miner.Name)
+	})
+
+	t.Run("submit transactions", func(t *testing.T) {
+		client := newTestClient(&mockHTTPSequence{responses: []mockResponse{{err: context.Canceled}}})
+		_, err := client.SubmitTransactions(context.Background(), nil, []Transaction{*tx},
+			WithSubmitMinerSelector(NewPrioritySelector(MinerGorillaPool)))
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("query transaction", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		response, err := client.QueryTransaction(context.Background(), nil, testTx,
+			WithQueryMinerSelector(NewPrioritySelector(MinerGorillaPool)))
+		require.NoError(t, err)
+		assert.Equal(t, MinerGorillaPool, response.Miner.Name)
+	})
+
+	t.Run("only miners with the api type", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		client.(*Client).minerAPIs[0].APIs = client.(*Client).minerAPIs[0].APIs[:1] // Taal without Arc
+		_, err := client.SubmitTransaction(context.Background(), nil, tx, WithSubmitAPIType(Arc),
+			WithSubmitMinerSelector(MinerSelectorFunc(func(_ context.Context, _ ClientInterface, miners []*Miner) (*Miner, error) {
+				require.Len(t, miners, 1)
+				assert.Equal(t, MinerGorillaPool, miners[0].Name)
+				return nil, nil
+			})))
+		require.ErrorIs(t, err, ErrNoMinerSelected)
+	})
+
+	t.Run("nil miner without a selector", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		_, err := client.SubmitTransaction(context.Background(), nil, tx)
+		require.Error(t, err)
+
+		_, err = client.QueryTransaction(context.Background(), nil, testTx)
+		require.Error(t, err)
+	})
+}
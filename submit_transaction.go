@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
+	"github.com/libsv/go-bt/v2"
 	"github.com/tonicpow/go-minercraft/v2/apis/arc"
 	"github.com/tonicpow/go-minercraft/v2/apis/mapi"
 )
@@ -72,17 +75,29 @@ type UnifiedSubmissionPayload struct {
 	TxSecondMempoolExpiry     int64                  `json:"txSecondMempoolExpiry"`
 	// FailureRetryable if true indicates the tx can be resubmitted to mAPI.
 	FailureRetryable bool `json:"failureRetryable"`
+	// RejectionKind is the classified ResultDescription when the ReturnResult is a failure.
+	RejectionKind mapi.RejectionKind `json:"rejectionKind,omitempty"`
+	// AlreadyKnown is set when the transaction was already known (see WithIdempotentSubmit).
+	AlreadyKnown bool `json:"alreadyKnown,omitempty"`
 
 	// Arc
-	BlockHash   string       `json:"blockHash,omitempty"`
-	BlockHeight int64        `json:"blockHeight,omitempty"`
-	ExtraInfo   string       `json:"extraInfo,omitempty"`
-	Status      int          `json:"status,omitempty"`
-	Title       string       `json:"title,omitempty"`
-	TxStatus    arc.TxStatus `json:"txStatus,omitempty"`
+	BlockHash    string       `json:"blockHash,omitempty"`
+	BlockHeight  int64        `json:"blockHeight,omitempty"`
+	CompetingTxs []string     `json:"competingTxs,omitempty"`
+	ExtraInfo    string       `json:"extraInfo,omitempty"`
+	Status       int          `json:"status,omitempty"`
+	Title        string       `json:"title,omitempty"`
+	TxStatus     arc.TxStatus `json:"txStatus,omitempty"`
+
+	// PackageResults are the results for every transaction in a submitted BEEF package (Arc)
+	PackageResults []UnifiedTx `json:"packageResults,omitempty"`
 }
 
 // Transaction is the body contents in the "submit transaction" request
+//
+// Tx can be set instead of RawTx (see NewExtendedTransaction), it is then submitted
+// in Extended Format to Arc and as a raw tx to mAPI. BEEF can be set instead of
+// RawTx (see NewBEEFTransaction), it is only supported by Arc.
 type Transaction struct {
 	CallBackEncryption string       `json:"callBackEncryption,omitempty"`
 	CallBackToken      string       `json:"callBackToken,omitempty"`
@@ -91,7 +106,60 @@ type Transaction struct {
 	MerkleFormat       string       `json:"merkleFormat,omitempty"`
 	MerkleProof        bool         `json:"merkleProof,omitempty"`
 	RawTx              string       `json:"rawtx"`
+	Tx                 *bt.Tx       `json:"-"`
+	BEEF               *BEEF        `json:"-"`
 	WaitForStatus      arc.TxStatus `json:"waitForStatus,omitempty"`
+
+	// Arc only options (sent as headers)
+	CallbackBatch           bool `json:"-"` // Batch the callbacks (X-CallbackBatch), "/batch" is appended to the CallBackURL
+	CumulativeFeeValidation bool `json:"-"` // Validate the fee of the unmined ancestors as well (X-CumulativeFeeValidation)
+	FullStatusUpdates       bool `json:"-"` // Send a callback for every status change (X-FullStatusUpdates)
+	MaxTimeout              int  `json:"-"` // Max seconds to wait for the WaitForStatus (X-MaxTimeout)
+	SkipFeeValidation       bool `json:"-"` // Skip the fee validation (X-SkipFeeValidation)
+	SkipScriptValidation    bool `json:"-"` // Skip the script validation (X-SkipScriptValidation)
+	SkipTxValidation        bool `json:"-"` // Skip all the tx validation (X-SkipTxValidation)
+}
+
+// SubmitTransactionOptFunc defines an optional argument that can be passed to the
+// SubmitTransaction and SubmitTransactions methods.
+type SubmitTransactionOptFunc func(o *submitTransactionOpts)
+
+type submitTransactionOpts struct {
+	apiType             APIType
+	idempotent          bool
+	minerSelector       MinerSelector
+	strictSingleRequest bool
+}
+
+// ErrMixedSubmitOptions is returned when a batch with different Arc options is submitted
+// in strict single request mode
+var ErrMixedSubmitOptions = errors.New("transactions have different submit options")
+
+func defaultSubmitOpts() *submitTransactionOpts {
+	return &submitTransactionOpts{}
+}
+
+// WithSubmitAPIType will submit the transaction(s) using the given API type instead of
+// the miner's preferred API type or the client API type.
+func WithSubmitAPIType(apiType APIType) SubmitTransactionOptFunc {
+	return func(o *submitTransactionOpts) {
+		o.apiType = apiType
+	}
+}
+
+// WithSubmitMinerSelector will use the selector to pick the miner when the given miner is nil
+func WithSubmitMinerSelector(selector MinerSelector) SubmitTransactionOptFunc {
+	return func(o *submitTransactionOpts) {
+		o.minerSelector = selector
+	}
+}
+
+// WithStrictSingleRequest will make SubmitTransactions (Arc) fail with ErrMixedSubmitOptions
+// instead of splitting the batch into one request per set of transaction options.
+func WithStrictSingleRequest() SubmitTransactionOptFunc {
+	return func(o *submitTransactionOpts) {
+		o.strictSingleRequest = true
+	}
 }
 
 // SubmitTransaction will fire a Merchant API request to submit a given transaction
@@ -102,33 +170,49 @@ type Transaction struct {
 // message content for the purpose of signing responses.
 //
 // Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi#3-submit-transaction
-func (c *Client) SubmitTransaction(ctx context.Context, miner *Miner, tx *Transaction) (*SubmitTransactionResponse, error) {
+func (c *Client) SubmitTransaction(ctx context.Context, miner *Miner, tx *Transaction,
+	opts ...SubmitTransactionOptFunc) (*SubmitTransactionResponse, error) {
+
+	// Make sure we have a valid transaction
+	if tx == nil {
+		return nil, errors.New("transaction was nil")
+	}
+
+	submitOpts := defaultSubmitOpts()
+	for _, o := range opts {
+		o(submitOpts)
+	}
 
 	// Make sure we have a valid miner
-	if miner == nil {
-		return nil, errors.New("miner was nil")
+	miner, err := c.submitMiner(ctx, miner, submitOpts)
+	if err != nil {
+		return nil, err
 	}
 
 	// Make the HTTP request
-	result, err := submitTransaction(ctx, c, miner, tx)
+	result, err := submitTransaction(ctx, c, miner, tx, submitOpts)
 	if err != nil {
 		return nil, err
 	}
 
 	if result.Response.Error != nil {
+		if submitOpts.idempotent && isAlreadyKnownError(result.Response.Error) {
+			return c.alreadyKnownSubmitResponse(ctx, result, tx), nil
+		}
 		return nil, result.Response.Error
 	}
 
 	submitResponse := &SubmitTransactionResponse{
 		JSONEnvelope: JSONEnvelope{
-			APIType: c.apiType,
+			APIType: result.APIType,
 			Miner:   result.Miner,
 		},
 	}
 
 	var modelAdapter SubmitTxModelAdapter
+	var arcModels []arc.SubmitTxModel
 
-	switch c.apiType {
+	switch result.APIType {
 	case MAPI:
 		model := &SubmitTxMapiAdapter{}
 		err = submitResponse.process(result.Miner, result.Response.BodyContents)
@@ -147,21 +231,24 @@ func (c *Client) SubmitTransaction(ctx context.Context, miner *Miner, tx *Transa
 
 		modelAdapter = &SubmitTxMapiAdapter{SubmitTxModel: model.SubmitTxModel}
 	case Arc:
-		model := &SubmitTxArcAdapter{}
-		err = json.Unmarshal(result.Response.BodyContents, model)
-		if err != nil {
+		if arcModels, err = parseArcSubmitTxModels(result.Response.BodyContents); err != nil {
 			return nil, err
 		}
 
-		modelAdapter = &SubmitTxArcAdapter{SubmitTxModel: model.SubmitTxModel}
+		modelAdapter = &SubmitTxArcAdapter{SubmitTxModel: arcSubjectTxModel(arcModels, tx)}
 	default:
-		return nil, fmt.Errorf("unknown API type: %s", c.apiType)
+		return nil, fmt.Errorf("unknown API type: %s", result.APIType)
 	}
 
 	submitResponse.Results = modelAdapter.GetSubmitTxResponse()
 
+	// Map the results of every transaction in the BEEF package
+	if tx.BEEF != nil && len(arcModels) > 0 {
+		submitResponse.Results.PackageResults = arcPackageResults(arcModels)
+	}
+
 	// Valid?
-	if submitResponse.Results == nil && (len(submitResponse.Payload) <= 0 && c.apiType == MAPI) {
+	if submitResponse.Results == nil && (len(submitResponse.Payload) <= 0 && result.APIType == MAPI) {
 		return nil, errors.New("failed getting submit response from: " + miner.Name)
 	}
 
@@ -172,35 +259,48 @@ func (c *Client) SubmitTransaction(ctx context.Context, miner *Miner, tx *Transa
 
 	submitResponse.Validated = isValid
 
+	// Already known transactions are a success in idempotent mode
+	if submitOpts.idempotent && submitResponse.Results.RejectionKind == mapi.RejectionAlreadyKnown {
+		c.alreadyKnownStatus(ctx, result.Miner, result.APIType, submitResponse.Results)
+	}
+
 	// Return the fully parsed response
 	return submitResponse, nil
 }
 
+// submitMiner will return the miner, or select one if it's nil and a selector is set
+func (c *Client) submitMiner(ctx context.Context, miner *Miner, opts *submitTransactionOpts) (*Miner, error) {
+	if miner != nil {
+		return miner, nil
+	}
+	if opts.minerSelector == nil {
+		return nil, errors.New("miner was nil")
+	}
+	return c.selectMiner(ctx, opts.minerSelector, opts.apiType)
+}
+
 // submitTransaction will fire the HTTP request to submit a transaction
-func submitTransaction(ctx context.Context, client *Client, miner *Miner, tx *Transaction) (*internalResult, error) {
+func submitTransaction(ctx context.Context, client *Client, miner *Miner, tx *Transaction,
+	opts *submitTransactionOpts) (*internalResult, error) {
 	result := &internalResult{Miner: miner}
 
-	api, err := client.MinerAPIByMinerID(miner.MinerID, client.apiType)
+	api, err := client.minerAPI(miner, opts.apiType)
 	if err != nil {
 		result.Response = &RequestResponse{Error: err}
 		return nil, err
 	}
+	result.APIType = api.Type
 
-	route, err := ActionRouteByAPIType(SubmitTx, client.apiType)
+	route, err := ActionRouteByAPIType(SubmitTx, api.Type)
 	if err != nil {
 		result.Response = &RequestResponse{Error: err}
 		return nil, err
 	}
 
-	submitURL := api.URL + route
-	httpPayload := &httpPayload{
-		Method:  http.MethodPost,
-		URL:     submitURL,
-		Token:   api.Token,
-		Headers: make(map[string]string),
-	}
+	httpPayload := newAPIPayload(api, http.MethodPost, api.URL+route)
+	httpPayload.Headers = make(map[string]string)
 
-	switch client.apiType {
+	switch api.Type {
 	case MAPI:
 		err = proceedMapiSubmitTx(tx, httpPayload)
 		if err != nil {
@@ -214,7 +314,7 @@ func submitTransaction(ctx context.Context, client *Client, miner *Miner, tx *Tr
 		}
 
 	default:
-		return nil, fmt.Errorf("unknown API type: %s", client.apiType)
+		return nil, fmt.Errorf("unknown API type: %s", api.Type)
 	}
 
 	result.Response = httpRequest(ctx, client, httpPayload)
@@ -223,37 +323,114 @@ func submitTransaction(ctx context.Context, client *Client, miner *Miner, tx *Tr
 
 // proceedArcSubmitTx will proceed with the Arc submit tx
 func proceedArcSubmitTx(tx *Transaction, httpPayload *httpPayload) error {
-	body := map[string]string{
-		"rawTx": tx.RawTx,
+	if tx.BEEF != nil {
+		// BEEF is submitted as binary
+		data, err := tx.BEEF.Bytes()
+		if err != nil {
+			return err
+		}
+		httpPayload.Data = data
+		httpPayload.Headers["Content-Type"] = contentTypeOctetStream
+	} else {
+		rawTx, err := arcRawTx(tx)
+		if err != nil {
+			return err
+		}
+
+		body := map[string]string{
+			"rawTx": rawTx,
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshall JSON when submitting transaction: %w", err)
+		}
+		httpPayload.Data = data
 	}
-	data, err := json.Marshal(body)
+
+	for key, value := range arcSubmitHeaders(tx) {
+		httpPayload.Headers[key] = value
+	}
+
+	return nil
+}
+
+// arcCallbackBatchSuffix is the path suffix Arc expects on the callback url for batched callbacks
+const arcCallbackBatchSuffix = "/batch"
+
+// arcCallbackURL will return the callback url, with the batch suffix if the callbacks are batched
+func arcCallbackURL(tx *Transaction) string {
+	if !tx.CallbackBatch {
+		return tx.CallBackURL
+	}
+
+	callbackURL, err := url.Parse(tx.CallBackURL)
 	if err != nil {
-		return fmt.Errorf("failed to marshall JSON when submitting transaction: %w", err)
+		return tx.CallBackURL
 	}
-	httpPayload.Data = data
+	if !strings.HasSuffix(callbackURL.Path, arcCallbackBatchSuffix) {
+		callbackURL.Path = strings.TrimSuffix(callbackURL.Path, "/") + arcCallbackBatchSuffix
+	}
+	return callbackURL.String()
+}
+
+// arcSubmitHeaders will return the Arc headers for the transaction options
+func arcSubmitHeaders(tx *Transaction) map[string]string {
+	headers := make(map[string]string)
 
 	if tx.MerkleProof {
-		httpPayload.Headers["X-MerkleProof"] = "true"
+		headers["X-MerkleProof"] = "true"
 	}
 
 	if tx.CallBackURL != "" {
-		httpPayload.Headers["X-CallbackUrl"] = tx.CallBackURL
+		headers["X-CallbackUrl"] = arcCallbackURL(tx)
 	}
 
 	if tx.CallBackToken != "" {
-		httpPayload.Headers["X-CallbackToken"] = tx.CallBackToken
+		headers["X-CallbackToken"] = tx.CallBackToken
+	}
+
+	if tx.CallbackBatch {
+		headers["X-CallbackBatch"] = "true"
+	}
+
+	if tx.FullStatusUpdates {
+		headers["X-FullStatusUpdates"] = "true"
 	}
 
 	if statusCode, ok := arc.MapTxStatusToInt(tx.WaitForStatus); ok {
-		httpPayload.Headers["X-WaitForStatus"] = strconv.Itoa(statusCode)
+		headers["X-WaitForStatus"] = strconv.Itoa(statusCode)
 	}
 
-	return nil
+	if tx.MaxTimeout > 0 {
+		headers["X-MaxTimeout"] = strconv.Itoa(tx.MaxTimeout)
+	}
+
+	if tx.SkipFeeValidation {
+		headers["X-SkipFeeValidation"] = "true"
+	}
+
+	if tx.SkipScriptValidation {
+		headers["X-SkipScriptValidation"] = "true"
+	}
+
+	if tx.SkipTxValidation {
+		headers["X-SkipTxValidation"] = "true"
+	}
+
+	if tx.CumulativeFeeValidation {
+		headers["X-CumulativeFeeValidation"] = "true"
+	}
+
+	return headers
 }
 
 // proceedMapiSubmitTx will proceed with the mAPI submit tx
 func proceedMapiSubmitTx(tx *Transaction, httpPayload *httpPayload) error {
-	data, err := json.Marshal(tx)
+	if tx.BEEF != nil {
+		return ErrBEEFNotSupported
+	}
+
+	data, err := json.Marshal(mapiTransaction(*tx))
 	if err != nil {
 		return err
 	}
@@ -276,18 +453,20 @@ func (a *SubmitTxMapiAdapter) GetSubmitTxResponse() *UnifiedSubmissionPayload {
 		TxID:                      a.TxID,
 		TxSecondMempoolExpiry:     a.TxSecondMempoolExpiry,
 		FailureRetryable:          a.FailureRetryable,
+		RejectionKind:             mapi.RejectionKindOf(a.ReturnResult, a.ResultDescription),
 	}
 }
 
 // GetSubmitTxResponse will return the unified response for Arc adapter
 func (a *SubmitTxArcAdapter) GetSubmitTxResponse() *UnifiedSubmissionPayload {
 	return &UnifiedSubmissionPayload{
-		BlockHash:   a.BlockHash,
-		BlockHeight: a.BlockHeight,
-		ExtraInfo:   a.ExtraInfo,
-		Status:      a.Status,
-		Title:       a.Title,
-		TxStatus:    a.TxStatus,
-		TxID:        a.TxID,
+		BlockHash:    a.BlockHash,
+		BlockHeight:  a.BlockHeight,
+		CompetingTxs: a.CompetingTxs,
+		ExtraInfo:    a.ExtraInfo,
+		Status:       a.Status,
+		Title:        a.Title,
+		TxStatus:     a.TxStatus,
+		TxID:         a.TxID,
 	}
 }
@@ -11,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tonicpow/go-minercraft/v2/apis/arc"
+	"github.com/tonicpow/go-minercraft/v2/apis/mapi"
 )
 
 const submitTestSignature = "3045022100f65ae83b20bc60e7a5f0e9c1bd9aceb2b26962ad0ee35472264e83e059f4b9be022010ca2334ff088d6e085eb3c2118306e61ec97781e8e1544e75224533dcc32379"
@@ -43,6 +45,37 @@ func (m *mockHTTPValidSubmission) Do(req *http.Request) (*http.Response, error)
 	return resp, nil
 }
 
+// mockHTTPValidArcSubmission for mocking requests
+type mockHTTPValidArcSubmission struct{}
+
+// Do is a mock http request
+func (m *mockHTTPValidArcSubmission) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusBadRequest
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	// Valid response
+	if strings.HasSuffix(req.URL.String(), "/v1/tx") {
+		resp.StatusCode = http.StatusOK
+		resp.Body = io.NopCloser(bytes.NewBufferString(`{
+		"blockHash": "",
+		"blockHeight": 0,
+		"extraInfo": "",
+		"status": 200,
+		"timestamp": "2023-08-10T13:49:07.308687569Z",
+		"title": "OK",
+		"txStatus": "SEEN_ON_NETWORK",
+		"txid": "6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0"}`))
+	}
+
+	// Default is valid
+	return resp, nil
+}
+
 // mockHTTPBadSubmission for mocking requests
 type mockHTTPBadSubmission struct{}
 
@@ -106,6 +139,57 @@ func TestClient_SubmitTransaction(t *testing.T) {
 		assert.Equal(t, "0.1.0", response.Results.APIVersion)
 		assert.Equal(t, QueryTransactionSuccess, response.Results.ReturnResult)
 		assert.Equal(t, "6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0", response.Results.TxID)
+		assert.Empty(t, response.Results.RejectionKind)
+	})
+
+	t.Run("rejected transaction", func(t *testing.T) {
+		client := newTestClient(&MockClient{MockDo: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(bytes.NewBufferString(`{"payload": "{\"txid\":\"` + testTx +
+					`\",\"returnResult\":\"failure\",\"resultDescription\":\"258: txn-mempool-conflict\"}"}`)),
+			}, nil
+		}})
+
+		response, err := client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), tx)
+		require.NoError(t, err)
+		assert.Equal(t, QueryTransactionFailure, response.Results.ReturnResult)
+		assert.Equal(t, mapi.RejectionDoubleSpend, response.Results.RejectionKind)
+	})
+
+	t.Run("submit using arc for a single call", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidArcSubmission{})
+		response, err := client.SubmitTransaction(
+			context.Background(), client.MinerByName(MinerGorillaPool), tx, WithSubmitAPIType(Arc),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, response)
+
+		assert.Equal(t, Arc, response.APIType)
+		assert.Equal(t, arc.SeenOnNetwork, response.Results.TxStatus)
+		assert.Equal(t, "6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0", response.Results.TxID)
+	})
+
+	t.Run("submit using the miner api preference", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidArcSubmission{})
+		miner := client.MinerByName(MinerGorillaPool)
+		require.NoError(t, client.SetMinerAPIPreference(miner.MinerID, Arc))
+
+		response, err := client.SubmitTransaction(context.Background(), miner, tx)
+		require.NoError(t, err)
+		assert.Equal(t, Arc, response.APIType)
+	})
+
+	t.Run("api type not configured for miner", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidArcSubmission{})
+		err := client.AddMiner(Miner{Name: testMinerName, MinerID: testMinerID}, []API{{URL: testMinerURL, Type: MAPI}})
+		require.NoError(t, err)
+
+		response, err := client.SubmitTransaction(
+			context.Background(), client.MinerByName(testMinerName), tx, WithSubmitAPIType(Arc),
+		)
+		require.Error(t, err)
+		assert.Nil(t, response)
 	})
 
 	t.Run("invalid miner", func(t *testing.T) {
@@ -115,6 +199,17 @@ func TestClient_SubmitTransaction(t *testing.T) {
 		assert.Nil(t, response)
 	})
 
+	t.Run("nil transaction", func(t *testing.T) {
+		for _, client := range []ClientInterface{
+			newTestClient(&mockHTTPValidSubmission{}),
+			newTestArcClient(&mockHTTPValidArcSubmission{}),
+		} {
+			response, err := client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), nil)
+			require.Error(t, err)
+			assert.Nil(t, response)
+		}
+	})
+
 	t.Run("http error", func(t *testing.T) {
 		client := newTestClient(&mockHTTPError{})
 		response, err := client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), tx)
@@ -151,6 +246,122 @@ func TestClient_SubmitTransaction(t *testing.T) {
 	})
 }
 
+// TestClient_SubmitTransaction_ArcHeaders tests the Arc options are sent as headers
+func TestClient_SubmitTransaction_ArcHeaders(t *testing.T) {
+	t.Parallel()
+
+	tx := Transaction{
+		RawTx:                   submitTestExampleTx,
+		CallBackURL:             "https://example.com/callback",
+		CallBackToken:           "token",
+		CallbackBatch:           true,
+		CumulativeFeeValidation: true,
+		FullStatusUpdates:       true,
+		MaxTimeout:              30,
+		MerkleProof:             true,
+		SkipFeeValidation:       true,
+		SkipScriptValidation:    true,
+		SkipTxValidation:        true,
+		WaitForStatus:           arc.SeenOnNetwork,
+	}
+
+	expected := map[string]string{
+		"X-CallbackUrl":             "https://example.com/callback/batch",
+		"X-CallbackToken":           "token",
+		"X-CallbackBatch":           "true",
+		"X-CumulativeFeeValidation": "true",
+		"X-FullStatusUpdates":       "true",
+		"X-MaxTimeout":              "30",
+		"X-MerkleProof":             "true",
+		"X-SkipFeeValidation":       "true",
+		"X-SkipScriptValidation":    "true",
+		"X-SkipTxValidation":        "true",
+		"X-WaitForStatus":           "8",
+	}
+
+	t.Run("single submit", func(t *testing.T) {
+		httpClient := &mockHTTPCaptureSubmission{}
+		client := newTestArcClient(httpClient)
+		_, err := client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), &tx)
+		require.NoError(t, err)
+
+		for key, value := range expected {
+			assert.Equal(t, value, httpClient.headers.Get(key), key)
+		}
+	})
+
+	t.Run("batch submit", func(t *testing.T) {
+		httpClient := &mockHTTPCaptureSubmission{}
+		client := newTestArcClient(httpClient)
+		_, err := client.SubmitTransactions(context.Background(), client.MinerByName(MinerGorillaPool), []Transaction{tx})
+		require.NoError(t, err)
+
+		for key, value := range expected {
+			assert.Equal(t, value, httpClient.headers.Get(key), key)
+		}
+	})
+
+	t.Run("no options, no headers", func(t *testing.T) {
+		httpClient := &mockHTTPCaptureSubmission{}
+		client := newTestArcClient(httpClient)
+		_, err := client.SubmitTransaction(
+			context.Background(), client.MinerByName(MinerGorillaPool), &Transaction{RawTx: submitTestExampleTx},
+		)
+		require.NoError(t, err)
+
+		for key := range expected {
+			assert.Empty(t, httpClient.headers.Get(key), key)
+		}
+	})
+
+	t.Run("not sent to mapi", func(t *testing.T) {
+		httpClient := &mockHTTPCaptureSubmission{}
+		client := newTestClient(httpClient)
+		_, err := client.SubmitTransaction(context.Background(), client.MinerByName(MinerGorillaPool), &tx)
+		require.NoError(t, err)
+
+		assert.NotContains(t, string(httpClient.body), "skip")
+		assert.Empty(t, httpClient.headers.Get("X-SkipFeeValidation"))
+	})
+}
+
+// TestArcCallbackURL tests the method arcCallbackURL()
+func TestArcCallbackURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tx       Transaction
+		expected string
+	}{
+		"not batched": {
+			tx:       Transaction{CallBackURL: "https://example.com/callback"},
+			expected: "https://example.com/callback",
+		},
+		"batched": {
+			tx:       Transaction{CallBackURL: "https://example.com/callback", CallbackBatch: true},
+			expected: "https://example.com/callback/batch",
+		},
+		"batched with trailing slash": {
+			tx:       Transaction{CallBackURL: "https://example.com/callback/", CallbackBatch: true},
+			expected: "https://example.com/callback/batch",
+		},
+		"batched with query": {
+			tx:       Transaction{CallBackURL: "https://example.com/callback?id=1", CallbackBatch: true},
+			expected: "https://example.com/callback/batch?id=1",
+		},
+		"already suffixed": {
+			tx:       Transaction{CallBackURL: "https://example.com/callback/batch", CallbackBatch: true},
+			expected: "https://example.com/callback/batch",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, arcCallbackURL(&test.tx))
+		})
+	}
+}
+
 // ExampleClient_SubmitTransaction example using SubmitTransaction()
 func ExampleClient_SubmitTransaction() {
 	// Create a client (using a test client vs NewClient())
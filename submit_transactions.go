@@ -6,7 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/tonicpow/go-minercraft/v2/apis/arc"
@@ -55,73 +56,205 @@ type (
 
 		// FailureRetryable if true indicates the tx can be resubmitted to mAPI.
 		FailureRetryable bool `json:"failureRetryable"`
+		// RejectionKind is the classified ResultDescription when the ReturnResult is a failure
+		// (with Arc the Title and ExtraInfo when the Status is an error).
+		RejectionKind mapi.RejectionKind `json:"rejectionKind,omitempty"`
+		// AlreadyKnown is set when the transaction was already known (see WithIdempotentSubmit).
+		AlreadyKnown bool `json:"alreadyKnown,omitempty"`
 
 		// Arc specific fields
-		BlockHash   string       `json:"blockHash,omitempty"`
-		BlockHeight int64        `json:"blockHeight,omitempty"`
-		ExtraInfo   string       `json:"extraInfo,omitempty"`
-		Status      int          `json:"status,omitempty"`
-		Timestamp   time.Time    `json:"timestamp,omitempty"`
-		Title       string       `json:"title,omitempty"`
-		TxStatus    arc.TxStatus `json:"txStatus,omitempty"`
+		BlockHash    string       `json:"blockHash,omitempty"`
+		BlockHeight  int64        `json:"blockHeight,omitempty"`
+		CompetingTxs []string     `json:"competingTxs,omitempty"`
+		ExtraInfo    string       `json:"extraInfo,omitempty"`
+		Status       int          `json:"status,omitempty"`
+		Timestamp    time.Time    `json:"timestamp,omitempty"`
+		Title        string       `json:"title,omitempty"`
+		TxStatus     arc.TxStatus `json:"txStatus,omitempty"`
 	}
 )
 
 // SubmitTransactions is used for submitting batched transactions
 //
+// With Arc the transaction options (callbacks, merkle proof, validation...) are sent as
+// headers, so the transactions are grouped by options and one request is made per group.
+// The results are returned in the original order. Use WithStrictSingleRequest to get an
+// error instead when the options differ.
+//
+// If a group fails after other groups were already sent to Arc, the response is returned
+// with the error: it contains the results of the sent groups, and the transactions that
+// were not sent have an empty TxID.
+//
 // Reference: https://github.com/bitcoin-sv-specs/brfc-merchantapi#5-submit-multiple-transactions
-func (c *Client) SubmitTransactions(ctx context.Context, miner *Miner, txs []Transaction) (*SubmitTransactionsResponse, error) {
-	if miner == nil {
-		return nil, errors.New("miner was nil")
+func (c *Client) SubmitTransactions(ctx context.Context, miner *Miner, txs []Transaction,
+	opts ...SubmitTransactionOptFunc) (*SubmitTransactionsResponse, error) {
+	submitOpts := defaultSubmitOpts()
+	for _, o := range opts {
+		o(submitOpts)
+	}
+
+	miner, err := c.submitMiner(ctx, miner, submitOpts)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(txs) <= 0 {
 		return nil, errors.New("no transactions")
 	}
 
-	response, err := submitTransactions(ctx, c, miner, txs)
+	api, err := c.minerAPI(miner, submitOpts.apiType)
 	if err != nil {
 		return nil, err
 	}
 
-	switch c.apiType {
+	switch api.Type {
 	case MAPI:
+		var response *RequestResponse
+		if response, err = submitTransactions(ctx, c, api, txs); err != nil {
+			return nil, err
+		}
+		if response.Error != nil {
+			return nil, response.Error
+		}
+
 		var raw RawSubmitTransactionsResponse
 		if err = json.Unmarshal(response.BodyContents, &raw); err != nil {
 			return nil, err
 		}
 
-		return parseRawSubmitTransactionsResponse(raw)
+		var result *SubmitTransactionsResponse
+		if result, err = parseRawSubmitTransactionsResponse(raw); err != nil {
+			return nil, err
+		}
+
+		// Already known transactions are a success in idempotent mode
+		if submitOpts.idempotent {
+			c.alreadyKnownTxs(ctx, miner, MAPI, result)
+		}
+		return result, nil
 
 	case Arc:
-		return processArcSubmitTransactionsResponse(response)
+		var result *SubmitTransactionsResponse
+		result, err = submitArcTransactions(ctx, c, api, txs, submitOpts)
+
+		// Already known transactions are a success in idempotent mode (also for partial results)
+		if result != nil && submitOpts.idempotent {
+			c.alreadyKnownTxs(ctx, miner, Arc, result)
+		}
+		return result, err
 
 	default:
-		return nil, fmt.Errorf("unknown API type: %s", c.apiType)
+		return nil, fmt.Errorf("unknown API type: %s", api.Type)
+	}
+}
+
+// arcTxGroup is a group of transactions that share the same Arc headers
+type arcTxGroup struct {
+	indexes []int // Position of the transactions in the original batch
+	txs     []Transaction
+}
+
+// groupArcTransactions will group the transactions by their Arc headers (keeping the order)
+func groupArcTransactions(txs []Transaction) []*arcTxGroup {
+	groups := make([]*arcTxGroup, 0, 1)
+	groupsByKey := make(map[string]*arcTxGroup)
+	for i := range txs {
+		key := arcHeadersKey(arcSubmitHeaders(&txs[i]))
+		group, ok := groupsByKey[key]
+		if !ok {
+			group = &arcTxGroup{}
+			groupsByKey[key] = group
+			groups = append(groups, group)
+		}
+		group.indexes = append(group.indexes, i)
+		group.txs = append(group.txs, txs[i])
+	}
+	return groups
+}
+
+// arcHeadersKey will return a unique key for a set of headers
+func arcHeadersKey(headers map[string]string) string {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sb := strings.Builder{}
+	for _, key := range keys {
+		sb.WriteString(key + "=" + headers[key] + "\n")
 	}
+	return sb.String()
 }
 
-// submitTransactions submits the transactions to the miner.
-func submitTransactions(ctx context.Context, client *Client, miner *Miner, txs []Transaction) (*RequestResponse, error) {
-	api, err := client.MinerAPIByMinerID(miner.MinerID, client.apiType)
+// submitArcTransactions will submit the transactions to Arc, one request per group of
+// transactions sharing the same options, and merge the results in the original order
+//
+// If a group fails after other groups were sent, the results of the sent groups are
+// returned with the error (the transactions that were not sent have an empty TxID)
+func submitArcTransactions(ctx context.Context, client *Client, api *API, txs []Transaction,
+	opts *submitTransactionOpts) (*SubmitTransactionsResponse, error) {
+
+	groups := groupArcTransactions(txs)
+	if len(groups) > 1 && opts.strictSingleRequest {
+		return nil, ErrMixedSubmitOptions
+	}
+
+	result := &SubmitTransactionsResponse{
+		Payload: UnifiedTxsPayload{
+			Txs: make([]UnifiedTx, len(txs)),
+		},
+	}
+	for sent, group := range groups {
+		if err := submitArcTxGroup(ctx, client, api, group, result.Payload.Txs); err != nil {
+			if sent == 0 {
+				return nil, err
+			}
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// submitArcTxGroup will submit a group of transactions to Arc and set their results
+func submitArcTxGroup(ctx context.Context, client *Client, api *API, group *arcTxGroup,
+	unifiedTxs []UnifiedTx) error {
+
+	response, err := submitTransactions(ctx, client, api, group.txs)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if response.Error != nil {
+		return response.Error
 	}
 
-	var route string
-	if route, err = ActionRouteByAPIType(SubmitTxs, client.apiType); err != nil {
-		return nil, err
+	var result *SubmitTransactionsResponse
+	if result, err = processArcSubmitTransactionsResponse(response); err != nil {
+		return err
+	}
+	if len(result.Payload.Txs) != len(group.txs) {
+		return fmt.Errorf(
+			"expected %d results from Arc, got %d", len(group.txs), len(result.Payload.Txs),
+		)
 	}
 
-	submitURL := api.URL + route
-	payload := &httpPayload{
-		Method:  http.MethodPost,
-		URL:     submitURL,
-		Token:   api.Token,
-		Headers: make(map[string]string),
+	for i, index := range group.indexes {
+		unifiedTxs[index] = result.Payload.Txs[i]
 	}
+	return nil
+}
 
-	switch client.apiType {
+// submitTransactions submits the transactions to the miner API.
+func submitTransactions(ctx context.Context, client *Client, api *API, txs []Transaction) (*RequestResponse, error) {
+	route, err := ActionRouteByAPIType(SubmitTxs, api.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := newAPIPayload(api, http.MethodPost, api.URL+route)
+	payload.Headers = make(map[string]string)
+
+	switch api.Type {
 	case MAPI:
 		if err = proceedMapiSubmitTxs(
 			txs, payload,
@@ -137,21 +270,24 @@ func submitTransactions(ctx context.Context, client *Client, miner *Miner, txs [
 		}
 
 	default:
-		return nil, fmt.Errorf("unknown API type: %s", client.apiType)
+		return nil, fmt.Errorf("unknown API type: %s", api.Type)
 	}
 
 	response := httpRequest(ctx, client, payload)
 	return response, nil
 }
 
+// proceedArcSubmitTxs prepares the payload for Arc.
+//
+// All the transactions must share the same options, the headers are taken from the first one.
 func proceedArcSubmitTxs(txs []Transaction, httpPayload *httpPayload) error {
-	var rawTxs = make([]string, 0, len(txs))
-	for _, tx := range txs {
-		rawTxs = append(rawTxs, tx.RawTx)
-	}
-
-	body := map[string]interface{}{
-		"rawTx": rawTxs,
+	var body = make([]map[string]string, 0, len(txs))
+	for i := range txs {
+		rawTx, err := arcRawTx(&txs[i])
+		if err != nil {
+			return err
+		}
+		body = append(body, map[string]string{"rawTx": rawTx})
 	}
 
 	data, err := json.Marshal(body)
@@ -161,20 +297,8 @@ func proceedArcSubmitTxs(txs []Transaction, httpPayload *httpPayload) error {
 
 	httpPayload.Data = data
 
-	if txs[0].MerkleProof {
-		httpPayload.Headers["X-MerkleProof"] = "true"
-	}
-
-	if txs[0].CallBackURL != "" {
-		httpPayload.Headers["X-CallbackUrl"] = txs[0].CallBackURL
-	}
-
-	if txs[0].CallBackToken != "" {
-		httpPayload.Headers["X-CallbackToken"] = txs[0].CallBackToken
-	}
-
-	if statusCode, ok := arc.MapTxStatusToInt(txs[0].WaitForStatus); ok {
-		httpPayload.Headers["X-WaitForStatus"] = strconv.Itoa(statusCode)
+	for key, value := range arcSubmitHeaders(&txs[0]) {
+		httpPayload.Headers[key] = value
 	}
 
 	return nil
@@ -182,7 +306,15 @@ func proceedArcSubmitTxs(txs []Transaction, httpPayload *httpPayload) error {
 
 // proceedMapiSubmitTxs prepares the payload for MAPI.
 func proceedMapiSubmitTxs(txs []Transaction, httpPayload *httpPayload) error {
-	data, err := json.Marshal(txs)
+	mapiTxs := make([]Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if tx.BEEF != nil {
+			return ErrBEEFNotSupported
+		}
+		mapiTxs = append(mapiTxs, mapiTransaction(tx))
+	}
+
+	data, err := json.Marshal(mapiTxs)
 	if err != nil {
 		return err
 	}
@@ -206,6 +338,9 @@ func parseRawSubmitTransactionsResponse(raw RawSubmitTransactionsResponse) (*Sub
 	); err != nil {
 		return nil, err
 	}
+	for i := range payload.Txs {
+		payload.Txs[i].RejectionKind = mapi.RejectionKindOf(payload.Txs[i].ReturnResult, payload.Txs[i].ResultDescription)
+	}
 	result.Payload = payload
 
 	return result, nil
@@ -213,16 +348,23 @@ func parseRawSubmitTransactionsResponse(raw RawSubmitTransactionsResponse) (*Sub
 
 // convertArcSubmitTxModelToUnifiedTx converts Arc's SubmitTxModel to UnifiedTx.
 func convertArcSubmitTxModelToUnifiedTx(arcTxModel arc.SubmitTxModel) UnifiedTx {
-	return UnifiedTx{
-		BlockHash:   arcTxModel.BlockHash,
-		BlockHeight: arcTxModel.BlockHeight,
-		ExtraInfo:   arcTxModel.ExtraInfo,
-		Status:      arcTxModel.Status,
-		Timestamp:   arcTxModel.Timestamp,
-		Title:       arcTxModel.Title,
-		TxStatus:    arcTxModel.TxStatus,
-		TxID:        arcTxModel.TxID,
+	unifiedTx := UnifiedTx{
+		BlockHash:    arcTxModel.BlockHash,
+		BlockHeight:  arcTxModel.BlockHeight,
+		CompetingTxs: arcTxModel.CompetingTxs,
+		ExtraInfo:    arcTxModel.ExtraInfo,
+		Status:       arcTxModel.Status,
+		Timestamp:    arcTxModel.Timestamp,
+		Title:        arcTxModel.Title,
+		TxStatus:     arcTxModel.TxStatus,
+		TxID:         arcTxModel.TxID,
+	}
+
+	// Classify the failed transactions (Arc returns an error status per transaction)
+	if arcTxModel.Status >= http.StatusBadRequest {
+		unifiedTx.RejectionKind = mapi.ClassifyRejection(arcTxModel.Title + " " + arcTxModel.ExtraInfo)
 	}
+	return unifiedTx
 }
 
 // processArcSubmitTransactionsResponse processes the response for Arc.
@@ -3,9 +3,11 @@ package minercraft
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -66,6 +68,7 @@ func TestClient_SubmitTransactions(t *testing.T) {
 						TxID:              "3145011f34a00d0666ea265b87c8e44108f87d3b53b853976906519ee8e1475f",
 						ReturnResult:      "failure",
 						ResultDescription: "Missing inputs",
+						RejectionKind:     mapi.RejectionMissingInputs,
 						ConflictedWith: []mapi.ConflictedWith{{
 							TxID: "86e1b384d3d169fd6aa4d34cf2d6f487436da54154befaab5a1fb25f844d65a8",
 							Size: 191,
@@ -107,3 +110,133 @@ func TestClient_SubmitTransactions(t *testing.T) {
 		})
 	}
 }
+
+// TestClient_SubmitTransactions_ArcGroups tests the Arc batch is split by transaction options
+func TestClient_SubmitTransactions_ArcGroups(t *testing.T) {
+	t.Parallel()
+
+	txs := []Transaction{
+		{RawTx: "01", CallBackURL: "https://example.com/a"},
+		{RawTx: "02", CallBackURL: "https://example.com/b"},
+		{RawTx: "03", CallBackURL: "https://example.com/a"},
+	}
+
+	// newMock will return a mock that echoes the raw txs back as txids
+	newMock := func(requests *[]*http.Request, bodies *[][]map[string]string) *MockClient {
+		return &MockClient{MockDo: func(req *http.Request) (*http.Response, error) {
+			var body []map[string]string
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			*requests = append(*requests, req)
+			*bodies = append(*bodies, body)
+
+			results := make([]string, 0, len(body))
+			for _, tx := range body {
+				results = append(results, `{"status":200,"txStatus":"SEEN_ON_NETWORK","txid":"`+tx["rawTx"]+`"}`)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("[" + strings.Join(results, ",") + "]")),
+			}, nil
+		}}
+	}
+
+	t.Run("one request per group, results in order", func(t *testing.T) {
+		var requests []*http.Request
+		var bodies [][]map[string]string
+		client := newTestArcClient(newMock(&requests, &bodies))
+
+		response, err := client.SubmitTransactions(context.Background(), client.MinerByName(MinerGorillaPool), txs)
+		require.NoError(t, err)
+
+		require.Len(t, requests, 2)
+		assert.Equal(t, "https://example.com/a", requests[0].Header.Get("X-CallbackUrl"))
+		assert.Equal(t, []map[string]string{{"rawTx": "01"}, {"rawTx": "03"}}, bodies[0])
+		assert.Equal(t, "https://example.com/b", requests[1].Header.Get("X-CallbackUrl"))
+		assert.Equal(t, []map[string]string{{"rawTx": "02"}}, bodies[1])
+
+		require.Len(t, response.Payload.Txs, 3)
+		for i, tx := range txs {
+			assert.Equal(t, tx.RawTx, response.Payload.Txs[i].TxID)
+		}
+	})
+
+	t.Run("same options, single request", func(t *testing.T) {
+		var requests []*http.Request
+		var bodies [][]map[string]string
+		client := newTestArcClient(newMock(&requests, &bodies))
+
+		_, err := client.SubmitTransactions(
+			context.Background(), client.MinerByName(MinerGorillaPool), []Transaction{txs[0], txs[2]},
+			WithStrictSingleRequest(),
+		)
+		require.NoError(t, err)
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("strict single request with mixed options", func(t *testing.T) {
+		var requests []*http.Request
+		var bodies [][]map[string]string
+		client := newTestArcClient(newMock(&requests, &bodies))
+
+		_, err := client.SubmitTransactions(
+			context.Background(), client.MinerByName(MinerGorillaPool), txs, WithStrictSingleRequest(),
+		)
+		require.ErrorIs(t, err, ErrMixedSubmitOptions)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		client := newTestArcClient(&mockHTTPError{})
+		_, err := client.SubmitTransactions(context.Background(), client.MinerByName(MinerGorillaPool), txs)
+		require.Error(t, err)
+	})
+
+	t.Run("failed group returns the sent results", func(t *testing.T) {
+		var requests []*http.Request
+		var bodies [][]map[string]string
+		mock := newMock(&requests, &bodies)
+		client := newTestArcClient(&MockClient{MockDo: func(req *http.Request) (*http.Response, error) {
+			if len(requests) > 0 {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"status":400,"title":"Bad request"}`)),
+				}, nil
+			}
+			return mock.Do(req)
+		}})
+
+		response, err := client.SubmitTransactions(context.Background(), client.MinerByName(MinerGorillaPool), txs)
+		require.Error(t, err)
+		require.NotNil(t, response)
+		require.Len(t, response.Payload.Txs, 3)
+		assert.Equal(t, "01", response.Payload.Txs[0].TxID)
+		assert.Empty(t, response.Payload.Txs[1].TxID)
+		assert.Equal(t, "03", response.Payload.Txs[2].TxID)
+	})
+
+	t.Run("failed first group", func(t *testing.T) {
+		client := newTestArcClient(&MockClient{MockDo: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"status":400,"title":"Bad request"}`)),
+			}, nil
+		}})
+
+		response, err := client.SubmitTransactions(context.Background(), client.MinerByName(MinerGorillaPool), txs)
+		require.Error(t, err)
+		assert.Nil(t, response)
+	})
+
+	t.Run("missing results", func(t *testing.T) {
+		client := newTestArcClient(&MockClient{MockDo: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("[]")),
+			}, nil
+		}})
+		_, err := client.SubmitTransactions(context.Background(), client.MinerByName(MinerGorillaPool), txs)
+		require.Error(t, err)
+	})
+}